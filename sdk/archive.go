@@ -0,0 +1,325 @@
+package sdk
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// IgnoreFileNames Files at the root of a source directory whose patterns exclude files from the archive
+var IgnoreFileNames = []string{".gitignore", ".mdsignore"}
+
+// archiveModTime Fixed modification time written for every archive entry so identical sources produce
+// identical archives
+var archiveModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ignorePattern A single gitignore style pattern
+type ignorePattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreMatcher Decides which paths, relative to the source root, are excluded from an archive
+type ignoreMatcher struct {
+	patterns []ignorePattern
+}
+
+// compileIgnorePattern Converts a gitignore style pattern into a regular expression. Supports comments,
+// negation with "!", directory only patterns with a trailing "/", root anchoring with a leading or inner "/"
+// and the "*", "?", "[...]" and "**" wildcards.
+func compileIgnorePattern(line string) (*ignorePattern, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, false
+	}
+
+	pattern := &ignorePattern{}
+	if strings.HasPrefix(line, "!") {
+		pattern.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		pattern.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return nil, false
+	}
+
+	var expr strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch ch := line[i]; ch {
+		case '*':
+			if strings.HasPrefix(line[i:], "**/") {
+				expr.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(line[i:], "**") {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(line[i:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := line[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	prefix := "^(?:.*/)?"
+	if anchored {
+		prefix = "^"
+	}
+	re, err := regexp.Compile(prefix + expr.String() + "$")
+	if err != nil {
+		return nil, false
+	}
+	pattern.re = re
+	return pattern, true
+}
+
+// newIgnoreMatcher Builds a matcher from the ignore files at the root of dir followed by the extra patterns
+func newIgnoreMatcher(dir string, extraPatterns []string) (*ignoreMatcher, error) {
+	matcher := &ignoreMatcher{}
+	matcher.add(".git/")
+
+	for _, name := range IgnoreFileNames {
+		file, err := os.Open(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			matcher.add(scanner.Text())
+		}
+		file.Close()
+		if err = scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, line := range extraPatterns {
+		matcher.add(line)
+	}
+	return matcher, nil
+}
+
+func (m *ignoreMatcher) add(line string) {
+	if pattern, ok := compileIgnorePattern(line); ok {
+		m.patterns = append(m.patterns, *pattern)
+	}
+}
+
+// ignored Reports whether the slash separated relative path is excluded. The last matching pattern wins.
+func (m *ignoreMatcher) ignored(relPath string, isDir bool) bool {
+	ignored := false
+	for _, pattern := range m.patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+		if pattern.re.MatchString(relPath) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// writeSourceArchive Zips the files within dir, honoring ignore patterns, into w. Entries are written in
+// lexical order with fixed timestamps and permissions so identical sources produce identical archives.
+func writeSourceArchive(w io.Writer, dir string, extraPatterns []string) error {
+	matcher, err := newIgnoreMatcher(dir, extraPatterns)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if matcher.ignored(relPath, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		header := &zip.FileHeader{
+			Name:     relPath,
+			Method:   zip.Deflate,
+			Modified: archiveModTime,
+		}
+		mode := fs.FileMode(0644)
+		if info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		header.SetMode(mode)
+
+		entryWriter, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(entryWriter, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// UploadProgressFunc Reports the bytes of source sent so far. Total is -1 when the size is not known up front,
+// i.e. while a directory is archived on the fly.
+type UploadProgressFunc func(sent int64, total int64)
+
+// progressWriter Reports the bytes written through it to an UploadProgressFunc
+type progressWriter struct {
+	w        io.Writer
+	sent     int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.sent += int64(n)
+	if n > 0 {
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// functionSource Source of a function code archive. Opened before an upload starts so missing or unreadable
+// sources are reported before any request is sent.
+type functionSource struct {
+	path           string
+	name           string
+	size           int64
+	file           *os.File
+	ignorePatterns []string
+}
+
+// openFunctionSource Opens the archive file, or prepares the directory to be archived, at sourcePath
+func openFunctionSource(sourcePath string, ignorePatterns []string) (*functionSource, error) {
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return &functionSource{
+			path:           sourcePath,
+			name:           filepath.Base(filepath.Clean(sourcePath)) + ".zip",
+			size:           -1,
+			ignorePatterns: ignorePatterns,
+		}, nil
+	}
+
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return &functionSource{path: sourcePath, name: filepath.Base(sourcePath), size: info.Size(), file: file}, nil
+}
+
+// writeTo Streams the archive to w, zipping directories on the fly
+func (s *functionSource) writeTo(w io.Writer, progress UploadProgressFunc) error {
+	if progress != nil {
+		w = &progressWriter{w: w, total: s.size, progress: progress}
+	}
+
+	if s.file == nil {
+		return writeSourceArchive(w, s.path, s.ignorePatterns)
+	}
+
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := io.Copy(w, s.file)
+	return err
+}
+
+// hash Content hash of the archive in the form "sha256:<hex>"
+func (s *functionSource) hash() (string, error) {
+	hash := sha256.New()
+	if err := s.writeTo(hash, nil); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *functionSource) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// FunctionSourceHash Content hash of the archive UpdateFunctionCode would upload for the source path. Directories
+// are hashed through their deterministic archive so unchanged sources always produce the same hash.
+func FunctionSourceHash(sourcePath string, ignorePatterns []string) (string, error) {
+	source, err := openFunctionSource(sourcePath, ignorePatterns)
+	if err != nil {
+		return "", err
+	}
+	defer source.Close()
+
+	return source.hash()
+}
@@ -0,0 +1,262 @@
+package sdk
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeSourceTree(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Unexpected error writing %s: %s", name, err)
+		}
+	}
+	return dir
+}
+
+func archiveEntries(t *testing.T, archive []byte) []string {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("Unexpected error reading archive: %s", err)
+	}
+
+	names := make([]string, 0, len(reader.File))
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	return names
+}
+
+func TestIgnoreMatcher(t *testing.T) {
+	matcher := &ignoreMatcher{}
+	for _, line := range []string{"# comment", "*.log", "!keep.log", "node_modules/", "/build", "docs/**/*.md"} {
+		matcher.add(line)
+	}
+
+	cases := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"debug.log", false, true},
+		{"src/debug.log", false, true},
+		{"keep.log", false, false},
+		{"node_modules", true, true},
+		{"src/node_modules", true, true},
+		{"node_modules", false, false},
+		{"build", true, true},
+		{"src/build", true, false},
+		{"docs/a/b/readme.md", false, true},
+		{"docs/readme.md", false, true},
+		{"src/index.js", false, false},
+	}
+	for _, c := range cases {
+		if got := matcher.ignored(c.path, c.isDir); got != c.ignored {
+			t.Errorf("ignored(%q, %v) = %v, expected %v", c.path, c.isDir, got, c.ignored)
+		}
+	}
+}
+
+func TestWriteSourceArchiveHonorsIgnoreFiles(t *testing.T) {
+	dir := writeSourceTree(t, map[string]string{
+		".gitignore":                "node_modules/\n*.log\n",
+		".mdsignore":                "!important.log\n",
+		"index.js":                  "module.exports = {}",
+		"lib/util.js":               "exports.util = 1",
+		"debug.log":                 "noise",
+		"important.log":             "keep",
+		"node_modules/dep/index.js": "dep",
+		".git/HEAD":                 "ref: refs/heads/main",
+		"secrets.env":               "KEY=1",
+	})
+
+	archive := &bytes.Buffer{}
+	if err := writeSourceArchive(archive, dir, []string{"*.env"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	got := strings.Join(archiveEntries(t, archive.Bytes()), ",")
+	assertString(t, got, ".gitignore,.mdsignore,important.log,index.js,lib/util.js", "Archive entries incorrect")
+}
+
+func TestWriteSourceArchiveIsDeterministic(t *testing.T) {
+	dir := writeSourceTree(t, map[string]string{
+		"index.js":    "module.exports = {}",
+		"lib/util.js": "exports.util = 1",
+	})
+
+	first := &bytes.Buffer{}
+	if err := writeSourceArchive(first, dir, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(dir, "index.js"), later, later)
+
+	second := &bytes.Buffer{}
+	if err := writeSourceArchive(second, dir, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("Expected identical sources to produce identical archives")
+	}
+	names := archiveEntries(t, first.Bytes())
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("Expected sorted entries, got %v", names)
+	}
+}
+
+func TestUpdateFunctionCodeArchivesDirectory(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	dir := writeSourceTree(t, map[string]string{
+		"index.js":     "module.exports = {}",
+		"README.md":    "docs",
+		"node_modules": "",
+	})
+
+	err := client.UpdateFunctionCode(&UpdateFunctionCodeArgs{
+		Orid:             functionOrid,
+		Runtime:          "node",
+		EntryPoint:       "index:main",
+		SourcePathOrFile: dir,
+		IgnorePatterns:   []string{"*.md"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	functions := srv.Functions()
+	assertInt(t, len(functions), 1, "Function count incorrect")
+	got := strings.Join(archiveEntries(t, functions[0].Source), ",")
+	assertString(t, got, "index.js,node_modules", "Uploaded archive entries incorrect")
+}
+
+func TestUpdateFunctionCodeMissingSource(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+
+	err := client.UpdateFunctionCode(&UpdateFunctionCodeArgs{
+		Orid:             functionOrid,
+		Runtime:          "node",
+		EntryPoint:       "index:main",
+		SourcePathOrFile: filepath.Join(t.TempDir(), "missing.zip"),
+	})
+	if !os.IsNotExist(err) {
+		t.Errorf("Expected not exist error, got %v", err)
+	}
+}
+
+func TestFunctionSourceHashIgnoresExcludedFiles(t *testing.T) {
+	dir := writeSourceTree(t, map[string]string{".mdsignore": "*.log\n", "index.js": "one"})
+
+	first, err := FunctionSourceHash(dir, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	os.WriteFile(filepath.Join(dir, "debug.log"), []byte("noise"), 0644)
+	second, _ := FunctionSourceHash(dir, nil)
+	assertString(t, second, first, "Hash changed by ignored file")
+
+	os.WriteFile(filepath.Join(dir, "index.js"), []byte("two"), 0644)
+	third, _ := FunctionSourceHash(dir, nil)
+	if third == first {
+		t.Error("Expected hash to change with the source")
+	}
+}
+
+func TestUpdateFunctionCodeSkipsUnchangedSource(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	dir := writeSourceTree(t, map[string]string{"index.js": "module.exports = {}"})
+	args := &UpdateFunctionCodeArgs{
+		Orid:             functionOrid,
+		Runtime:          "node",
+		EntryPoint:       "index:main",
+		SourcePathOrFile: dir,
+		SkipUnchanged:    true,
+	}
+
+	upload := func() int {
+		if err := client.UpdateFunctionCode(args); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return srv.Functions()[0].Version
+	}
+
+	assertInt(t, upload(), 1, "Version after first upload incorrect")
+	assertInt(t, upload(), 1, "Unchanged source should not be uploaded")
+
+	args.EntryPoint = "index:handler"
+	assertInt(t, upload(), 2, "Changed entry point should be uploaded")
+
+	args.SkipUnchanged = false
+	assertInt(t, upload(), 3, "Unchanged source should be uploaded unless skipping is requested")
+}
+
+func TestUpdateFunctionCodeReportsProgress(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	source := filepath.Join(t.TempDir(), "source.zip")
+	content := bytes.Repeat([]byte("a"), 256*1024)
+	os.WriteFile(source, content, 0600)
+
+	var calls int
+	var sent, total int64
+	err := client.UpdateFunctionCode(&UpdateFunctionCodeArgs{
+		Orid:             functionOrid,
+		Runtime:          "node",
+		EntryPoint:       "index:main",
+		SourcePathOrFile: source,
+		Progress: func(s int64, t int64) {
+			calls++
+			sent, total = s, t
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if calls < 2 {
+		t.Errorf("Expected incremental progress, got %d calls", calls)
+	}
+	assertInt(t, int(sent), len(content), "Sent bytes incorrect")
+	assertInt(t, int(total), len(content), "Total bytes incorrect")
+	if !bytes.Equal(srv.Functions()[0].Source, content) {
+		t.Error("Uploaded source does not match")
+	}
+}
+
+func TestUpdateFunctionCodeReportsProgressForDirectories(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	dir := writeSourceTree(t, map[string]string{"index.js": "module.exports = {}"})
+
+	total := int64(0)
+	err := client.UpdateFunctionCode(&UpdateFunctionCodeArgs{
+		Orid:             functionOrid,
+		Runtime:          "node",
+		EntryPoint:       "index:main",
+		SourcePathOrFile: dir,
+		Progress:         func(_ int64, t int64) { total = t },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, int(total), -1, "Total bytes of a directory should be unknown")
+}
@@ -2,7 +2,7 @@ package sdk
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
 )
 
 // AuthManager Client to manage authorization credentials for various MDS Cloud calls
@@ -23,6 +25,7 @@ type AuthManager struct {
 	account           string
 	allowSelfSignCert bool
 	enableSemaphore   bool
+	httpConfig        *httpConfig
 }
 
 func defaultIfNilOrEmpty(value interface{}, def interface{}) interface{} {
@@ -51,12 +54,18 @@ var semaphore = make(chan int, 1)
 
 // GetAuthenticationToken Gets an authentication token to use against the MDS apis
 func (am *AuthManager) GetAuthenticationToken(overrides map[string]string) (string, error) {
+	return am.GetAuthenticationTokenContext(nil, overrides)
+}
+
+// GetAuthenticationTokenContext Gets an authentication token, sending any authenticate request with ctx so it is
+// traced and cancelled along with the call needing the token. A nil ctx sends the request without a context.
+func (am *AuthManager) GetAuthenticationTokenContext(ctx context.Context, overrides map[string]string) (string, error) {
 
 	if am.enableSemaphore {
 		semaphore <- 1
 	}
 
-	data, err := am.getAuthenticationTokenWork(overrides)
+	data, err := am.getAuthenticationTokenWork(ctx, overrides)
 
 	if am.enableSemaphore {
 		<-semaphore
@@ -65,7 +74,7 @@ func (am *AuthManager) GetAuthenticationToken(overrides map[string]string) (stri
 	return data, err
 }
 
-func (am *AuthManager) getAuthenticationTokenWork(overrides map[string]string) (string, error) {
+func (am *AuthManager) getAuthenticationTokenWork(ctx context.Context, overrides map[string]string) (string, error) {
 	account := defaultIfNilOrEmpty(overrides["accountId"], am.account).(string)
 	user := defaultIfNilOrEmpty(overrides["userId"], am.userID).(string)
 	password := defaultIfNilOrEmpty(overrides["password"], am.password).(string)
@@ -94,7 +103,7 @@ func (am *AuthManager) getAuthenticationTokenWork(overrides map[string]string) (
 	}
 
 	// Acquire new token
-	token, err := am.getNewToken(account, user, password)
+	token, err := am.getNewToken(ctx, account, user, password)
 	if err != nil {
 		return "", err
 	}
@@ -102,24 +111,29 @@ func (am *AuthManager) getAuthenticationTokenWork(overrides map[string]string) (
 	return token.(string), nil
 }
 
-func (am *AuthManager) getNewToken(account string, userName string, password string) (string, error) {
-	var client *http.Client
+// buildAuthenticatePayload Builds the JSON body sent to the identity authenticate endpoint
+func buildAuthenticatePayload(account string, userName string, password string) ([]byte, error) {
+	return json.Marshal(&AuthenticateArgs{
+		AccountID: account,
+		UserID:    userName,
+		Password:  password,
+	})
+}
+
+func (am *AuthManager) getNewToken(ctx context.Context, account string, userName string, password string) (string, error) {
+	client := am.httpConfig.newClient(orid.ServiceIdentity, API_TIMEOUT, am.allowSelfSignCert)
 
-	if am.allowSelfSignCert {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		client = &http.Client{Timeout: API_TIMEOUT, Transport: tr}
-	} else {
-		client = &http.Client{Timeout: API_TIMEOUT}
+	body, err := buildAuthenticatePayload(account, userName, password)
+	if err != nil {
+		return "", err
 	}
 
-	body := []byte(fmt.Sprintf(`{"accountId":"%s","userId":"%s","password":"%s"}`, account, userName, password))
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/authenticate", am.identityURL), bytes.NewBuffer(body))
 	if err != nil {
 		return "", errors.New("could not build request to authenticate user")
 	}
 
+	req = withOperation(ctx, req, "Authenticate", "")
 	req.Header.Set("Content-Type", "application/json")
 	r, err := client.Do(req)
 	if err != nil {
@@ -0,0 +1,205 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState State of a circuit breaker guarding a service
+type CircuitState int
+
+const (
+	// CircuitClosed Requests flow normally
+	CircuitClosed CircuitState = iota
+	// CircuitOpen Requests fail immediately with a *CircuitOpenError
+	CircuitOpen
+	// CircuitHalfOpen A single probe request is allowed through to test the service
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen Matches, through errors.Is, every *CircuitOpenError
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitOpenError Returned without contacting the service while its circuit breaker is open
+type CircuitOpenError struct {
+	Service    string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open for %s, retry after %s", e.Service, e.RetryAfter)
+}
+
+// Is Reports whether the target is ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerOptions Settings for the circuit breakers guarding each service
+//
+// FailureThreshold - Consecutive failures, transport errors or 5xx responses, that open the circuit. Defaults to 5.
+// OpenTimeout      - Time the circuit stays open before a probe is allowed. Defaults to 30 seconds.
+// OnStateChange    - Optional callback invoked after the circuit of a service changes state
+type CircuitBreakerOptions struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	OnStateChange    func(service string, from CircuitState, to CircuitState)
+}
+
+// circuitBreaker Tracks the health of a single service
+type circuitBreaker struct {
+	mu       sync.Mutex
+	service  string
+	opts     *CircuitBreakerOptions
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// circuitBreakers Lazily created circuit breakers keyed by orid service, i.e. "sf"
+type circuitBreakers struct {
+	mu       sync.Mutex
+	opts     CircuitBreakerOptions
+	breakers map[string]*circuitBreaker
+}
+
+func newCircuitBreakers(opts *CircuitBreakerOptions) *circuitBreakers {
+	breakers := circuitBreakers{opts: *opts, breakers: make(map[string]*circuitBreaker)}
+	if breakers.opts.FailureThreshold < 1 {
+		breakers.opts.FailureThreshold = 5
+	}
+	if breakers.opts.OpenTimeout <= 0 {
+		breakers.opts.OpenTimeout = 30 * time.Second
+	}
+
+	return &breakers
+}
+
+func (cb *circuitBreakers) get(service string) *circuitBreaker {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	breaker, ok := cb.breakers[service]
+	if !ok {
+		breaker = &circuitBreaker{service: service, opts: &cb.opts}
+		cb.breakers[service] = breaker
+	}
+	return breaker
+}
+
+// state Current state of the service's circuit, closed when the service has not been contacted
+func (cb *circuitBreakers) state(service string) CircuitState {
+	cb.mu.Lock()
+	breaker, ok := cb.breakers[service]
+	cb.mu.Unlock()
+	if !ok {
+		return CircuitClosed
+	}
+
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	return breaker.state
+}
+
+func (b *circuitBreaker) notify(from CircuitState, to CircuitState) {
+	if from != to && b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(b.service, from, to)
+	}
+}
+
+// allow Reports whether a request may be sent, moving an expired open circuit to half-open
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	from := b.state
+
+	switch b.state {
+	case CircuitOpen:
+		remaining := b.opts.OpenTimeout - time.Since(b.openedAt)
+		if remaining > 0 {
+			b.mu.Unlock()
+			return &CircuitOpenError{Service: b.service, RetryAfter: remaining}
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+	case CircuitHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return &CircuitOpenError{Service: b.service}
+		}
+		b.probing = true
+	}
+
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+	return nil
+}
+
+// record Updates the circuit with the outcome of a request
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	from := b.state
+	b.probing = false
+
+	if failed {
+		b.failures++
+		if b.state == CircuitHalfOpen || b.failures >= b.opts.FailureThreshold {
+			b.state = CircuitOpen
+			b.openedAt = time.Now()
+		}
+	} else {
+		b.failures = 0
+		b.state = CircuitClosed
+	}
+
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// release Frees the probe slot without counting the request, i.e. when the caller cancelled it
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// circuitBreakerTransport Fails fast while the service's circuit is open
+type circuitBreakerTransport struct {
+	next     http.RoundTripper
+	service  string
+	breakers *circuitBreakers
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	breaker := t.breakers.get(t.service)
+	if err := breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	r, err := t.next.RoundTrip(req)
+	if err != nil && (req.Context().Err() != nil || errors.Is(err, ErrRateLimited)) {
+		breaker.release()
+		return r, err
+	}
+
+	breaker.record(err != nil || r.StatusCode >= 500)
+	return r, err
+}
@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	healthy := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	transitions := make([]string, 0)
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{"sfUrl": srv.URL})
+	sdk.EnableCircuitBreaker(&CircuitBreakerOptions{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		OnStateChange: func(service string, from CircuitState, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	client := sdk.httpConfig.newClient("sf", API_TIMEOUT, false)
+
+	for i := 0; i < 2; i++ {
+		r, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		r.Body.Close()
+	}
+	if sdk.CircuitBreakerState("sf") != CircuitOpen {
+		t.Fatalf("Expected circuit to be open, got: %s", sdk.CircuitBreakerState("sf"))
+	}
+
+	_, err := client.Get(srv.URL)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected circuit open error, got: %v", err)
+	}
+
+	healthy = true
+	time.Sleep(30 * time.Millisecond)
+	r, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Expected half-open probe to be sent: %s", err)
+	}
+	r.Body.Close()
+
+	if sdk.CircuitBreakerState("sf") != CircuitClosed {
+		t.Errorf("Expected circuit to be closed, got: %s", sdk.CircuitBreakerState("sf"))
+	}
+	expected := []string{"closed->open", "open->half-open", "half-open->closed"}
+	assertInt(t, len(transitions), len(expected), "Transition count incorrect")
+	for i := range expected {
+		assertString(t, transitions[i], expected[i], "Transition incorrect")
+	}
+}
+
+func TestCircuitBreakerIsPerService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/sf/") {
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{
+		"sfUrl": srv.URL + "/sf",
+		"qsUrl": srv.URL + "/qs",
+	})
+	sdk.EnableCircuitBreaker(&CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	r, err := sdk.httpConfig.newClient("sf", API_TIMEOUT, false).Get(srv.URL + "/sf/v1/all")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	r.Body.Close()
+	if sdk.CircuitBreakerState("sf") != CircuitOpen {
+		t.Fatalf("Expected sf circuit to be open, got: %s", sdk.CircuitBreakerState("sf"))
+	}
+
+	r, err = sdk.httpConfig.newClient("qs", API_TIMEOUT, false).Get(srv.URL + "/qs/v1/queues")
+	if err != nil {
+		t.Fatalf("Expected qs requests to be unaffected by the sf circuit: %s", err)
+	}
+	r.Body.Close()
+	if sdk.CircuitBreakerState("qs") != CircuitClosed {
+		t.Errorf("Expected qs circuit to be closed, got: %s", sdk.CircuitBreakerState("qs"))
+	}
+}
+
+func TestEnableCircuitBreakerWhileClientsInUse(t *testing.T) {
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				sdk.httpConfig.newClient("sf", API_TIMEOUT, false)
+				sdk.CircuitBreakerState("sf")
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		sdk.EnableCircuitBreaker(&CircuitBreakerOptions{FailureThreshold: 3, OpenTimeout: time.Second})
+		sdk.EnableCircuitBreaker(nil)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,202 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdktest"
+)
+
+func newTestSdk(t *testing.T) (*Sdk, *sdktest.Server) {
+	srv := sdktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	sdk := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, srv.URLs())
+	return sdk, srv
+}
+
+func TestIdentityClientEndToEnd(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetIdentityClient()
+
+	registerResult, err := client.Register(&RegisterAccountArgs{
+		UserID:      "newUser",
+		Password:    "newPassword",
+		AccountName: "newAccount",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error registering: %s", err)
+	}
+
+	authResult, err := client.Authenticate(&AuthenticateArgs{
+		AccountID: registerResult.AccountID,
+		UserID:    "newUser",
+		Password:  "newPassword",
+	})
+	if err != nil || authResult.Token == "" {
+		t.Fatalf("Expected token, got error: %v", err)
+	}
+
+	if err = client.UpdateUser(&UpdateUserArgs{FriendlyName: "Updated"}); err != nil {
+		t.Errorf("Unexpected error updating user: %s", err)
+	}
+
+	impersonateResult, err := client.ImpersonateUser(&ImpersonateUserArgs{AccountID: registerResult.AccountID})
+	if err != nil || impersonateResult.Token == "" {
+		t.Errorf("Expected impersonation token, got error: %v", err)
+	}
+
+	if _, err = client.GetPublicSignature(); err != nil {
+		t.Errorf("Unexpected error fetching signature: %s", err)
+	}
+}
+
+func TestQueueServiceClientEndToEnd(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetQueueServiceClient()
+
+	createResult, err := client.CreateQueue(&CreateQueueArgs{Name: "testQueue", Resource: "resource"})
+	if err != nil {
+		t.Fatalf("Unexpected error creating queue: %s", err)
+	}
+	assertString(t, createResult.Status, "created", "Create status incorrect")
+
+	existsResult, err := client.CreateQueue(&CreateQueueArgs{Name: "testQueue"})
+	if err != nil {
+		t.Fatalf("Unexpected error creating queue: %s", err)
+	}
+	assertString(t, existsResult.Status, "exists", "Second create status incorrect")
+
+	err = client.UpdateQueue(&UpdateQueueArgs{Orid: createResult.Orid, Resource: "NULL"})
+	if err != nil {
+		t.Fatalf("Unexpected error updating queue: %s", err)
+	}
+
+	details, err := client.GetQueueDetails(&GetQueueDetailsArgs{Orid: createResult.Orid})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching queue: %s", err)
+	}
+	assertString(t, details.Resource, "", "Resource incorrect")
+
+	if err = client.DeleteQueue(&DeleteQueueArgs{Orid: createResult.Orid}); err != nil {
+		t.Errorf("Unexpected error deleting queue: %s", err)
+	}
+}
+
+func TestFileServiceClientEndToEnd(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+
+	createResult, err := client.CreateContainer(&CreateContainerArgs{Name: "testContainer"})
+	if err != nil {
+		t.Fatalf("Unexpected error creating container: %s", err)
+	}
+	if _, err = client.CreateContainer(&CreateContainerArgs{Name: "testContainer"}); err == nil {
+		t.Errorf("Expected error creating duplicate container")
+	}
+
+	if _, err = client.CreateContainer(&CreateContainerArgs{Name: "another"}); err != nil {
+		t.Fatalf("Unexpected error creating container: %s", err)
+	}
+	containers, err := client.ListContainers()
+	if err != nil {
+		t.Fatalf("Unexpected error listing containers: %s", err)
+	}
+	assertInt(t, len(containers), 2, "Container count incorrect")
+	assertString(t, containers[0].Name, "another", "Container name incorrect")
+	assertString(t, containers[1].Orid, createResult.Orid, "Container orid incorrect")
+
+	srv.PutFile(createResult.Orid, "sub/dir/file.txt", []byte("data"))
+	srv.PutFile(createResult.Orid, "root.txt", []byte("data"))
+
+	contents, err := client.ListContainerContents(&ListContainerContentsArgs{Orid: createResult.Orid})
+	if err != nil {
+		t.Fatalf("Unexpected error listing container: %s", err)
+	}
+	assertInt(t, len(contents.Directories), 1, "Directory count incorrect")
+	assertInt(t, len(contents.Files), 1, "File count incorrect")
+
+	err = client.DeleteContainerOrPath(&DeleteContainerArgs{Orid: createResult.Orid + "/sub"})
+	if err != nil {
+		t.Fatalf("Unexpected error deleting path: %s", err)
+	}
+	assertInt(t, len(srv.Files(createResult.Orid)), 1, "Remaining file count incorrect")
+
+	if err = client.DeleteContainerOrPath(&DeleteContainerArgs{Orid: createResult.Orid}); err != nil {
+		t.Errorf("Unexpected error deleting container: %s", err)
+	}
+	containers, _ = client.ListContainers()
+	assertInt(t, len(containers), 1, "Container count after delete incorrect")
+}
+
+func TestStateMachineServiceClientEndToEnd(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetStateMachineServiceClient()
+
+	definition := `{"Name":"test","StartsAt":"Success","States":{"Success":{"Type":"Succeed"}}}`
+	createResult, err := client.CreateStateMachine(&CreateStateMachineArgs{Definition: definition})
+	if err != nil {
+		t.Fatalf("Unexpected error creating state machine: %s", err)
+	}
+
+	updated := `{"Name":"updated","StartsAt":"Success","States":{"Success":{"Type":"Succeed"}}}`
+	_, err = client.UpdateStateMachine(&UpdateStateMachineArgs{Orid: createResult.Orid, Definition: updated})
+	if err != nil {
+		t.Fatalf("Unexpected error updating state machine: %s", err)
+	}
+
+	details, err := client.GetStateMachineDetails(&GetStateMachineDetailsArgs{Orid: createResult.Orid})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching state machine: %s", err)
+	}
+	assertString(t, details.Name, "updated", "Name incorrect")
+
+	if _, err = client.DeleteStateMachine(&DeleteStateMachineArgs{Orid: createResult.Orid}); err != nil {
+		t.Errorf("Unexpected error deleting state machine: %s", err)
+	}
+}
+
+func TestServerlessFunctionsClientEndToEnd(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+
+	summary, err := client.CreateFunction("test")
+	if err != nil {
+		t.Fatalf("Unexpected error creating function: %s", err)
+	}
+
+	functions, err := client.ListFunctions()
+	if err != nil {
+		t.Fatalf("Unexpected error listing functions: %s", err)
+	}
+	assertInt(t, len(*functions), 1, "Function count incorrect")
+
+	source := filepath.Join(t.TempDir(), "source.zip")
+	os.WriteFile(source, []byte("archive"), 0600)
+	err = client.UpdateFunctionCode(&UpdateFunctionCodeArgs{
+		Orid:             summary.Orid,
+		Runtime:          "node",
+		EntryPoint:       "src/one:main",
+		SourcePathOrFile: source,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error uploading code: %s", err)
+	}
+
+	result, err := client.InvokeFunction(summary.Orid, map[string]string{"name": "Frito"})
+	if err != nil {
+		t.Fatalf("Unexpected error invoking function: %s", err)
+	}
+	assertString(t, string(result.([]byte)), `{"name":"Frito"}`, "Invoke result incorrect")
+
+	details, err := client.GetFunctionDetails(summary.Orid)
+	if err != nil {
+		t.Fatalf("Unexpected error fetching function: %s", err)
+	}
+	assertString(t, details.Runtime, "node", "Runtime incorrect")
+
+	if err = client.DeleteFunction(summary.Orid); err != nil {
+		t.Errorf("Unexpected error deleting function: %s", err)
+	}
+}
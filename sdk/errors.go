@@ -0,0 +1,6 @@
+package sdk
+
+import "errors"
+
+// ErrNotFound Matches, through errors.Is, errors returned when the requested resource does not exist
+var ErrNotFound = errors.New("resource not found")
@@ -2,17 +2,34 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
 )
 
 // FileServiceClient Client to interact with the MDS Cloud container service
 type FileServiceClient struct {
 	fileServiceURL string
 	authManager    *AuthManager
+	httpConfig     *httpConfig
+	ctx            context.Context
+}
+
+// WithContext Returns a copy of the client whose requests carry the context, i.e. for cancellation and tracing
+func (cs *FileServiceClient) WithContext(ctx context.Context) FileServiceAPI {
+	return cs.withContext(ctx)
+}
+
+func (cs *FileServiceClient) withContext(ctx context.Context) *FileServiceClient {
+	client := *cs
+	client.ctx = ctx
+	return &client
 }
 
 // CreateContainerArgs Data needed to create a new container
@@ -27,18 +44,19 @@ type CreateContainerResult struct {
 
 // CreateContainer Attempts to create a new container with the MDS Cloud deployment
 func (cs *FileServiceClient) CreateContainer(data *CreateContainerArgs) (*CreateContainerResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	client := cs.httpConfig.newClient(orid.ServiceFile, API_TIMEOUT, false)
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/createContainer/%s", cs.fileServiceURL, data.Name), nil)
 	if err != nil {
 		return nil, errors.New("could not build request to create container")
 	}
 
-	token, err := cs.authManager.GetAuthenticationToken(nil)
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(cs.ctx, req, "CreateContainer", "")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -65,31 +83,115 @@ func (cs *FileServiceClient) CreateContainer(data *CreateContainerArgs) (*Create
 	}
 }
 
+// ContainerSummary Name and orid of a container
+type ContainerSummary struct {
+	Name string `json:"name"`
+	Orid string `json:"orid"`
+}
+
+// ListContainers Attempts to list the containers of the account with the MDS Cloud deployment
+func (cs *FileServiceClient) ListContainers() ([]ContainerSummary, error) {
+	client := cs.httpConfig.newClient(orid.ServiceFile, API_TIMEOUT, false)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/containers", cs.fileServiceURL), nil)
+	if err != nil {
+		return nil, errors.New("could not build request to list containers")
+	}
+
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = withOperation(cs.ctx, req, "ListContainers", "")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200:
+		payload := make([]ContainerSummary, 0)
+		err = json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			cs.httpConfig.debug("could not decode file service response", "error", err)
+			return nil, errors.New("could not decode response from API of resource")
+		}
+
+		return payload, nil
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
 // ListContainerContentsArgs Data needed to create a new container
+//
+// IncludeMetadata - Also return the size, modified time and content type of each file in FileDetails. Listing fails
+// when the service leaves any file out of FileDetails, i.e. a service that does not support metadata.
 type ListContainerContentsArgs struct {
-	Orid string `json:"orid"`
+	Orid            string `json:"orid"`
+	IncludeMetadata bool   `json:"-"`
 }
 
 // ListContainerContentsResult Create container results
 type ListContainerContentsResult struct {
-	Directories []string `json:"directories"`
-	Files       []string `json:"files"`
+	Directories []string   `json:"directories"`
+	Files       []string   `json:"files"`
+	FileDetails []FileInfo `json:"fileDetails,omitempty"`
+}
+
+// FileInfo Metadata describing a file within a container
+//
+// Checksum - Content hash in the form "sha256:<hex>", empty when the service does not report one
+type FileInfo struct {
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	Modified    time.Time `json:"modified"`
+	ContentType string    `json:"contentType"`
+	Checksum    string    `json:"checksum,omitempty"`
 }
 
 // ListContainerContents Attempts to create a new container with the MDS Cloud deployment
 func (cs *FileServiceClient) ListContainerContents(data *ListContainerContentsArgs) (*ListContainerContentsResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	contents, err := cs.listContainerContents(data)
+	if err != nil {
+		return nil, err
+	}
+	if data.IncludeMetadata {
+		if err = checkFileDetails(contents); err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+// listContainerContents Behaves like ListContainerContents but leaves files missing from FileDetails to the caller
+func (cs *FileServiceClient) listContainerContents(data *ListContainerContentsArgs) (*ListContainerContentsResult, error) {
+	if _, err := validateOrid(data.Orid, orid.ServiceFile); err != nil {
+		return nil, err
+	}
+
+	client := cs.httpConfig.newClient(orid.ServiceFile, API_TIMEOUT, false)
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/list/%s", cs.fileServiceURL, data.Orid), nil)
+	listURL := fmt.Sprintf("%s/v1/list/%s", cs.fileServiceURL, data.Orid)
+	if data.IncludeMetadata {
+		listURL += "?metadata=true"
+	}
+	req, err := http.NewRequest("GET", listURL, nil)
 	if err != nil {
 		return nil, errors.New("could not build request to create container")
 	}
 
-	token, err := cs.authManager.GetAuthenticationToken(nil)
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(cs.ctx, req, "ListContainerContents", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -108,12 +210,28 @@ func (cs *FileServiceClient) ListContainerContents(data *ListContainerContentsAr
 		}
 
 		return &payload, nil
+	case 404:
+		return nil, fmt.Errorf("container %s: %w", data.Orid, ErrNotFound)
 	default:
 		body, _ := io.ReadAll(r.Body)
 		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
 	}
 }
 
+// checkFileDetails Ensures every file of a listing has metadata, as zero values would pass for real ones
+func checkFileDetails(contents *ListContainerContentsResult) error {
+	described := make(map[string]bool, len(contents.FileDetails))
+	for _, info := range contents.FileDetails {
+		described[info.Name] = true
+	}
+	for _, name := range contents.Files {
+		if !described[name] {
+			return fmt.Errorf("file service did not return metadata for %s", name)
+		}
+	}
+	return nil
+}
+
 // DeleteContainerArgs Data needed to delete a container
 type DeleteContainerArgs struct {
 	Orid string `json:"orid"`
@@ -121,7 +239,11 @@ type DeleteContainerArgs struct {
 
 // DeleteContainerOrPath Attempts to delete a container or path within a container in the MDS Cloud deployment
 func (cs *FileServiceClient) DeleteContainerOrPath(data *DeleteContainerArgs) error {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if _, err := validateOrid(data.Orid, orid.ServiceFile); err != nil {
+		return err
+	}
+
+	client := cs.httpConfig.newClient(orid.ServiceFile, API_TIMEOUT, false)
 
 	body, err := json.Marshal(data)
 	if err != nil {
@@ -133,11 +255,12 @@ func (cs *FileServiceClient) DeleteContainerOrPath(data *DeleteContainerArgs) er
 		return errors.New("could not build request to create container")
 	}
 
-	token, err := cs.authManager.GetAuthenticationToken(nil)
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	req = withOperation(cs.ctx, req, "DeleteContainerOrPath", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
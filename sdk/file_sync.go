@@ -0,0 +1,518 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// DefaultSyncConcurrency Number of files SyncUp and SyncDown transfer at once when no concurrency is given
+const DefaultSyncConcurrency = 4
+
+// SyncCompareMode How SyncUp and SyncDown decide whether a file present on both sides has changed. Remote files
+// whose metadata the service does not report cannot be compared and are always treated as changed.
+type SyncCompareMode int
+
+const (
+	// SyncCompareSizeAndModTime Files differing in size, or whose source is newer than the destination, changed
+	SyncCompareSizeAndModTime SyncCompareMode = iota
+	// SyncCompareSize Only files differing in size changed
+	SyncCompareSize
+	// SyncCompareChecksum Files whose sha256 content hashes differ changed. Remote files without a reported
+	// checksum are always treated as changed.
+	SyncCompareChecksum
+)
+
+// SyncAction What a sync does to a single file at the destination
+type SyncAction string
+
+const (
+	SyncAdd    SyncAction = "add"
+	SyncUpdate SyncAction = "update"
+	SyncDelete SyncAction = "delete"
+)
+
+// SyncOperation A single file transferred to, or deleted from, the destination
+//
+// Path - Slash separated path relative to the synchronized directory
+// Size - Bytes transferred, zero for deletes
+type SyncOperation struct {
+	Action SyncAction
+	Path   string
+	Size   int64
+}
+
+// SyncProgress Reported after each operation completes. Err is the failure of that operation, if any.
+type SyncProgress struct {
+	Operation SyncOperation
+	Completed int
+	Total     int
+	Err       error
+}
+
+// SyncOptions Options controlling SyncUp and SyncDown
+//
+// Delete - Also delete destination files missing from the source. Directories emptied this way are removed.
+// DryRun - Only work out the operations, nothing is transferred or deleted
+// Concurrency - Number of files transferred at once, DefaultSyncConcurrency when zero
+// IgnorePatterns - Gitignore style patterns of paths neither transferred nor deleted on either side
+// Progress - Called after each operation, never concurrently
+type SyncOptions struct {
+	Compare        SyncCompareMode
+	Delete         bool
+	DryRun         bool
+	Concurrency    int
+	IgnorePatterns []string
+	Progress       func(SyncProgress)
+}
+
+// SyncResult The operations a sync performed, or would perform for a dry run, sorted by path
+type SyncResult struct {
+	Operations []SyncOperation
+}
+
+// Count Number of operations of the action
+func (r *SyncResult) Count(action SyncAction) int {
+	count := 0
+	for _, op := range r.Operations {
+		if op.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// syncFile A file on one side of a sync
+type syncFile struct {
+	size     int64
+	modified time.Time
+	checksum string
+	orid     string
+	unknown  bool
+}
+
+// SyncUp Makes the container or directory orid mirror the files within localDir. Files are compared according to
+// opts and transferred concurrently. Every operation is attempted, failures are returned joined together
+// alongside the result. Operations not started before the client's context, see WithContext, ends fail with its
+// error.
+func (cs *FileServiceClient) SyncUp(localDir string, containerOrid string, opts *SyncOptions) (*SyncResult, error) {
+	localDir = filepath.Clean(localDir)
+	root, err := validateOrid(containerOrid, orid.ServiceFile)
+	if err != nil {
+		return nil, err
+	}
+	opts, matcher := syncDefaults(opts)
+
+	local, err := localSyncFiles(localDir, matcher, false)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := cs.remoteSyncFiles(root, matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := func(relPath string, src *syncFile, dst *syncFile) (bool, error) {
+		switch opts.Compare {
+		case SyncCompareSize:
+			return src.size != dst.size, nil
+		case SyncCompareChecksum:
+			if src.size != dst.size || dst.checksum == "" {
+				return true, nil
+			}
+			sum, err := fileChecksum(filepath.Join(localDir, filepath.FromSlash(relPath)))
+			return sum != dst.checksum, err
+		default:
+			return src.size != dst.size || src.modified.After(dst.modified), nil
+		}
+	}
+	result, err := planSync(local, remote, opts, changed)
+	if err != nil || opts.DryRun {
+		return result, err
+	}
+
+	run := func(op SyncOperation) error {
+		if op.Action == SyncDelete {
+			return cs.DeleteContainerOrPath(&DeleteContainerArgs{Orid: remote[op.Path].orid})
+		}
+
+		file, err := os.Open(filepath.Join(localDir, filepath.FromSlash(op.Path)))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		dir := root
+		if parent := path.Dir(op.Path); parent != "." {
+			dir = root.Join(parent)
+		}
+		return cs.UploadFile(&UploadFileArgs{Orid: dir.String(), FileName: path.Base(op.Path), Source: file})
+	}
+	prune := func(dir string) error {
+		dirOrid := root.Join(dir).String()
+		contents, err := cs.ListContainerContents(&ListContainerContentsArgs{Orid: dirOrid})
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		if err != nil || len(contents.Directories) > 0 || len(contents.Files) > 0 {
+			return err
+		}
+		return cs.DeleteContainerOrPath(&DeleteContainerArgs{Orid: dirOrid})
+	}
+	return result, runSync(cs.ctx, result.Operations, opts, run, prune)
+}
+
+// SyncDown Makes localDir mirror the files within the container or directory orid, creating localDir when
+// missing. Downloaded files take the modified time reported by the service so later syncs see them unchanged.
+// Every operation is attempted, failures are returned joined together alongside the result.
+func (cs *FileServiceClient) SyncDown(containerOrid string, localDir string, opts *SyncOptions) (*SyncResult, error) {
+	localDir = filepath.Clean(localDir)
+	root, err := validateOrid(containerOrid, orid.ServiceFile)
+	if err != nil {
+		return nil, err
+	}
+	opts, matcher := syncDefaults(opts)
+
+	remote, err := cs.remoteSyncFiles(root, matcher)
+	if err != nil {
+		return nil, err
+	}
+	local, err := localSyncFiles(localDir, matcher, true)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := func(relPath string, src *syncFile, dst *syncFile) (bool, error) {
+		switch opts.Compare {
+		case SyncCompareSize:
+			return src.size != dst.size, nil
+		case SyncCompareChecksum:
+			if src.size != dst.size || src.checksum == "" {
+				return true, nil
+			}
+			sum, err := fileChecksum(filepath.Join(localDir, filepath.FromSlash(relPath)))
+			return sum != src.checksum, err
+		default:
+			return src.size != dst.size || src.modified.After(dst.modified), nil
+		}
+	}
+	result, err := planSync(remote, local, opts, changed)
+	if err != nil || opts.DryRun {
+		return result, err
+	}
+
+	run := func(op SyncOperation) error {
+		target := filepath.Join(localDir, filepath.FromSlash(op.Path))
+		if !insideDir(localDir, target) {
+			return fmt.Errorf("path %q is outside of %s", op.Path, localDir)
+		}
+		if op.Action == SyncDelete {
+			return os.Remove(target)
+		}
+		return cs.downloadTo(remote[op.Path], target)
+	}
+	prune := func(dir string) error {
+		target := filepath.Join(localDir, filepath.FromSlash(dir))
+		entries, err := os.ReadDir(target)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || len(entries) > 0 {
+			return err
+		}
+		return os.Remove(target)
+	}
+	return result, runSync(cs.ctx, result.Operations, opts, run, prune)
+}
+
+// insideDir Reports whether target lies below dir, which may be relative such as "."
+func insideDir(dir string, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
+
+// syncDefaults Fills in default options and builds the matcher for the ignore patterns
+func syncDefaults(opts *SyncOptions) (*SyncOptions, *ignoreMatcher) {
+	resolved := SyncOptions{}
+	if opts != nil {
+		resolved = *opts
+	}
+	if resolved.Concurrency <= 0 {
+		resolved.Concurrency = DefaultSyncConcurrency
+	}
+
+	matcher := &ignoreMatcher{}
+	for _, line := range resolved.IgnorePatterns {
+		matcher.add(line)
+	}
+	return &resolved, matcher
+}
+
+// localSyncFiles The regular files below dir keyed by slash separated relative path. A missing dir has no files
+// when allowMissing is set.
+func localSyncFiles(dir string, matcher *ignoreMatcher, allowMissing bool) (map[string]*syncFile, error) {
+	files := make(map[string]*syncFile)
+	if _, err := os.Stat(dir); allowMissing && os.IsNotExist(err) {
+		return files, nil
+	}
+
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath == dir {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if matcher.ignored(relPath, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		files[relPath] = &syncFile{size: info.Size(), modified: info.ModTime()}
+		return nil
+	})
+	return files, err
+}
+
+// remoteSyncFiles The files below the orid keyed by slash separated relative path. A directory that does not
+// exist yet has no files, a missing container is an error.
+func (cs *FileServiceClient) remoteSyncFiles(root *orid.Orid, matcher *ignoreMatcher) (map[string]*syncFile, error) {
+	files := make(map[string]*syncFile)
+	err := cs.WalkContainer(root.String(), func(relPath string, entry *ContainerEntry, err error) error {
+		if err != nil {
+			if relPath == "." && root.ResourceRider != "" && errors.Is(err, ErrNotFound) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+
+		if matcher.ignored(relPath, entry.IsDir) {
+			if entry.IsDir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !entry.IsDir {
+			files[relPath] = &syncFile{
+				size:     entry.Size,
+				modified: entry.Modified,
+				checksum: entry.Checksum,
+				orid:     entry.Orid,
+				unknown:  !entry.HasMetadata,
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// planSync Works out the operations making dst mirror src, sorted by path
+func planSync(src map[string]*syncFile, dst map[string]*syncFile, opts *SyncOptions, changed func(string, *syncFile, *syncFile) (bool, error)) (*SyncResult, error) {
+	result := &SyncResult{Operations: make([]SyncOperation, 0)}
+	for relPath, file := range src {
+		existing, ok := dst[relPath]
+		if !ok {
+			result.Operations = append(result.Operations, SyncOperation{Action: SyncAdd, Path: relPath, Size: file.size})
+			continue
+		}
+
+		isChanged := file.unknown || existing.unknown
+		if !isChanged {
+			var err error
+			if isChanged, err = changed(relPath, file, existing); err != nil {
+				return nil, err
+			}
+		}
+		if isChanged {
+			result.Operations = append(result.Operations, SyncOperation{Action: SyncUpdate, Path: relPath, Size: file.size})
+		}
+	}
+
+	if opts.Delete {
+		for relPath := range dst {
+			if _, ok := src[relPath]; !ok {
+				result.Operations = append(result.Operations, SyncOperation{Action: SyncDelete, Path: relPath})
+			}
+		}
+	}
+
+	sort.Slice(result.Operations, func(i, j int) bool {
+		return result.Operations[i].Path < result.Operations[j].Path
+	})
+	return result, nil
+}
+
+// runSync Performs the operations with at most opts.Concurrency running at once. Deletes run first, then prune
+// is given each directory left holding only deleted files, deepest first, to remove it once empty. This way a file
+// replaced by a directory of the same name, or the reverse, does not block the transfer.
+func runSync(ctx context.Context, operations []SyncOperation, opts *SyncOptions, run func(SyncOperation) error, prune func(dir string) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	deletes := make([]SyncOperation, 0)
+	transfers := make([]SyncOperation, 0, len(operations))
+	for _, op := range operations {
+		if op.Action == SyncDelete {
+			deletes = append(deletes, op)
+		} else {
+			transfers = append(transfers, op)
+		}
+	}
+
+	var mu sync.Mutex
+	var errs []error
+	completed := 0
+	record := func(op SyncOperation, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		completed++
+		if err != nil {
+			err = fmt.Errorf("%s %s: %w", op.Action, op.Path, err)
+			errs = append(errs, err)
+		}
+		if opts.Progress != nil {
+			opts.Progress(SyncProgress{Operation: op, Completed: completed, Total: len(operations), Err: err})
+		}
+	}
+
+	runPhase := func(phase []SyncOperation) {
+		limit := make(chan struct{}, opts.Concurrency)
+		var wg sync.WaitGroup
+		for _, op := range phase {
+			if err := ctx.Err(); err != nil {
+				record(op, err)
+				continue
+			}
+
+			limit <- struct{}{}
+			wg.Add(1)
+			go func(op SyncOperation) {
+				defer wg.Done()
+				defer func() { <-limit }()
+				record(op, run(op))
+			}(op)
+		}
+		wg.Wait()
+	}
+
+	runPhase(deletes)
+	for _, dir := range emptiedDirs(deletes, transfers) {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := prune(dir); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", SyncDelete, dir, err))
+		}
+	}
+	runPhase(transfers)
+	return errors.Join(errs...)
+}
+
+// emptiedDirs The directories, and their parents, holding deleted files but no transferred ones, deepest first
+func emptiedDirs(deletes []SyncOperation, transfers []SyncOperation) []string {
+	seen := make(map[string]bool)
+	for _, op := range transfers {
+		for dir := path.Dir(op.Path); dir != "." && !seen[dir]; dir = path.Dir(dir) {
+			seen[dir] = true
+		}
+	}
+
+	dirs := make([]string, 0)
+	for _, op := range deletes {
+		for dir := path.Dir(op.Path); dir != "." && !seen[dir]; dir = path.Dir(dir) {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		depthI, depthJ := strings.Count(dirs[i], "/"), strings.Count(dirs[j], "/")
+		if depthI != depthJ {
+			return depthI > depthJ
+		}
+		return dirs[i] < dirs[j]
+	})
+	return dirs
+}
+
+// downloadTo Downloads the remote file to target through a temporary file so an interrupted download never
+// leaves a partial file behind
+func (cs *FileServiceClient) downloadTo(file *syncFile, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err = cs.DownloadFile(file.orid, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), target); err != nil {
+		return err
+	}
+	if !file.modified.IsZero() {
+		return os.Chtimes(target, file.modified, file.modified)
+	}
+	return nil
+}
+
+// fileChecksum Content hash of the local file in the form "sha256:<hex>"
+func fileChecksum(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
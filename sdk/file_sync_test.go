@@ -0,0 +1,337 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdktest"
+)
+
+func syncOperations(result *SyncResult) string {
+	ops := make([]string, 0, len(result.Operations))
+	for _, op := range result.Operations {
+		ops = append(ops, fmt.Sprintf("%s %s", op.Action, op.Path))
+	}
+	return strings.Join(ops, ",")
+}
+
+func containerFiles(files map[string]string) string {
+	names := make([]string, 0, len(files))
+	for name, content := range files {
+		names = append(names, name+"="+content)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func TestUploadAndDownloadFile(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, nil)
+
+	err := client.UploadFile(&UploadFileArgs{Orid: containerOrid + "/docs", FileName: "readme.txt", Source: strings.NewReader("hello")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, string(srv.Files(containerOrid)["docs/readme.txt"].Content), "hello", "Uploaded content incorrect")
+
+	content := &bytes.Buffer{}
+	if err = client.DownloadFile(containerOrid+"/docs/readme.txt", content); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, content.String(), "hello", "Downloaded content incorrect")
+
+	err = client.DownloadFile(containerOrid+"/docs/missing.txt", content)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSyncUp(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{
+		"app.js":   "old",
+		"stale.js": "stale",
+	})
+	dir := writeSourceTree(t, map[string]string{
+		"app.js":          "new content",
+		"lib/util.js":     "util",
+		"debug.log":       "noise",
+		"assets/logo.svg": "svg",
+	})
+
+	opts := &SyncOptions{Delete: true, IgnorePatterns: []string{"*.log"}, Concurrency: 2}
+	result, err := client.SyncUp(dir, containerOrid, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, syncOperations(result), "update app.js,add assets/logo.svg,add lib/util.js,delete stale.js", "Operations incorrect")
+	assertString(t, containerFiles(fileContents(srv.Files(containerOrid))), "app.js=new content,assets/logo.svg=svg,lib/util.js=util", "Container files incorrect")
+
+	result, err = client.SyncUp(dir, containerOrid, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(result.Operations), 0, "Second sync should find nothing to do")
+}
+
+func TestSyncUpDryRun(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"stale.js": "stale"})
+	dir := writeSourceTree(t, map[string]string{"app.js": "app"})
+
+	result, err := client.SyncUp(dir, containerOrid+"/site", &SyncOptions{DryRun: true, Delete: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, syncOperations(result), "add app.js", "Operations incorrect")
+	assertInt(t, result.Count(SyncAdd), 1, "Add count incorrect")
+	assertString(t, containerFiles(fileContents(srv.Files(containerOrid))), "stale.js=stale", "Dry run should not change the container")
+}
+
+func TestSyncDown(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{
+		"app.js":      "app",
+		"lib/util.js": "util",
+	})
+	dir := filepath.Join(t.TempDir(), "mirror")
+
+	var progress []SyncProgress
+	opts := &SyncOptions{Delete: true, Progress: func(p SyncProgress) { progress = append(progress, p) }}
+	result, err := client.SyncDown(containerOrid, dir, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, syncOperations(result), "add app.js,add lib/util.js", "Operations incorrect")
+	assertInt(t, len(progress), 2, "Progress count incorrect")
+	assertInt(t, progress[1].Completed, 2, "Completed count incorrect")
+	assertInt(t, progress[1].Total, 2, "Total count incorrect")
+
+	content, _ := os.ReadFile(filepath.Join(dir, "lib", "util.js"))
+	assertString(t, string(content), "util", "Downloaded content incorrect")
+
+	result, err = client.SyncDown(containerOrid, dir, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(result.Operations), 0, "Second sync should find nothing to do")
+
+	os.WriteFile(filepath.Join(dir, "app.js"), []byte("local edit"), 0644)
+	os.WriteFile(filepath.Join(dir, "extra.js"), []byte("extra"), 0644)
+	result, err = client.SyncDown(containerOrid, dir, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, syncOperations(result), "update app.js,delete extra.js", "Operations incorrect")
+	content, _ = os.ReadFile(filepath.Join(dir, "app.js"))
+	assertString(t, string(content), "app", "Changed file not restored")
+	if _, err = os.Stat(filepath.Join(dir, "extra.js")); !os.IsNotExist(err) {
+		t.Errorf("Expected extra file to be deleted, got %v", err)
+	}
+}
+
+func TestSyncCompareModes(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"app.js": "aaa"})
+	dir := writeSourceTree(t, map[string]string{"app.js": "bbb"})
+	past := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(dir, "app.js"), past, past)
+
+	for _, c := range []struct {
+		mode     SyncCompareMode
+		expected string
+	}{
+		{SyncCompareSizeAndModTime, ""},
+		{SyncCompareSize, ""},
+		{SyncCompareChecksum, "update app.js"},
+	} {
+		result, err := client.SyncUp(dir, containerOrid, &SyncOptions{Compare: c.mode, DryRun: true})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		assertString(t, syncOperations(result), c.expected, fmt.Sprintf("Operations for mode %d incorrect", c.mode))
+	}
+}
+
+func TestSyncReportsFailures(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"a.txt": "a", "b.txt": "b"})
+	dir := filepath.Join(t.TempDir(), "mirror")
+	os.MkdirAll(filepath.Join(dir, "a.txt"), 0755)
+	os.WriteFile(filepath.Join(dir, "a.txt", "nested"), []byte("x"), 0644)
+
+	result, err := client.SyncDown(containerOrid, dir, nil)
+	if err == nil || !strings.Contains(err.Error(), "add a.txt") {
+		t.Fatalf("Expected failure of a.txt, got %v", err)
+	}
+	assertString(t, syncOperations(result), "add a.txt,add b.txt", "Operations incorrect")
+	content, _ := os.ReadFile(filepath.Join(dir, "b.txt"))
+	assertString(t, string(content), "b", "Remaining files should still be transferred")
+}
+
+func fileContents(files map[string]sdktest.File) map[string]string {
+	contents := make(map[string]string, len(files))
+	for name, f := range files {
+		contents[name] = string(f.Content)
+	}
+	return contents
+}
+
+func TestSyncDownRejectsEscapingNames(t *testing.T) {
+	_, srv := newTestSdk(t)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"directories":[],"files":["../escape.txt"],"fileDetails":[{"name":"../escape.txt","size":1}]}`))
+	}))
+	defer api.Close()
+
+	urls := srv.URLs()
+	urls["fsUrl"] = api.URL
+	client := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls).
+		GetFileServiceClient()
+	parent := t.TempDir()
+
+	_, err := client.SyncDown("orid:1:mdsCloud:::1001:fs:walk", filepath.Join(parent, "mirror"), nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid name") {
+		t.Errorf("Expected invalid name error, got %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(parent, "escape.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing written outside of the directory, got %v", err)
+	}
+}
+
+func TestSyncReplacesDirectoriesWithFiles(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	opts := &SyncOptions{Delete: true}
+
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"a": "file"})
+	dir := writeSourceTree(t, map[string]string{"a/b": "nested"})
+	result, err := client.SyncDown(containerOrid, dir, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, syncOperations(result), "add a,delete a/b", "Down operations incorrect")
+	content, _ := os.ReadFile(filepath.Join(dir, "a"))
+	assertString(t, string(content), "file", "Local file should replace the directory")
+
+	sdk, srv = newTestSdk(t)
+	client = sdk.GetFileServiceClient()
+	containerOrid = createTestContainer(t, client, srv, map[string]string{"a/b": "nested"})
+	dir = writeSourceTree(t, map[string]string{"a": "file"})
+	result, err = client.SyncUp(dir, containerOrid, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, syncOperations(result), "add a,delete a/b", "Up operations incorrect")
+	assertString(t, containerFiles(fileContents(srv.Files(containerOrid))), "a=file", "Remote file should replace the directory")
+}
+
+func TestSyncLimitsConcurrentTransfers(t *testing.T) {
+	_, srv := newTestSdk(t)
+	urls := srv.URLs()
+	target, _ := url.Parse(urls["fsUrl"])
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/upload/") || strings.HasPrefix(r.URL.Path, "/v1/download/") {
+			mu.Lock()
+			inFlight++
+			peak = max(peak, inFlight)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			time.Sleep(20 * time.Millisecond)
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	defer api.Close()
+
+	urls["fsUrl"] = api.URL
+	client := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls).
+		GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, nil)
+
+	files := make(map[string]string)
+	for i := 0; i < 8; i++ {
+		files[fmt.Sprintf("file%d.txt", i)] = "content"
+	}
+	opts := &SyncOptions{Concurrency: 2}
+
+	if _, err := client.SyncUp(writeSourceTree(t, files), containerOrid, opts); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, peak, 2, "Concurrent uploads incorrect")
+
+	peak = 0
+	if _, err := client.SyncDown(containerOrid, t.TempDir(), opts); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, peak, 2, "Concurrent downloads incorrect")
+}
+
+func TestSyncHonorsContext(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"app.js": "app"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.WithContext(ctx).SyncDown(containerOrid, t.TempDir(), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation error, got %v", err)
+	}
+}
+
+func TestSyncDownIntoWorkingDirectory(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"app.js": "app", "lib/util.js": "util"})
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	dir := t.TempDir()
+	if err = os.Chdir(dir); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	result, err := client.SyncDown(containerOrid, ".", &SyncOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, syncOperations(result), "add app.js,add lib/util.js", "Operations incorrect")
+	content, _ := os.ReadFile(filepath.Join(dir, "lib", "util.js"))
+	assertString(t, string(content), "util", "Downloaded content incorrect")
+
+	result, err = client.SyncDown(containerOrid, "", &SyncOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(result.Operations), 0, "Empty directory should mean the working directory")
+}
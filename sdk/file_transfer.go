@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// UploadFileArgs Data needed to upload a file into a container
+//
+// Orid - The container or directory orid the file is written to, missing directories are created
+// Source - Content of the file, streamed to the service
+type UploadFileArgs struct {
+	Orid     string
+	FileName string
+	Source   io.Reader
+}
+
+// UploadFile Attempts to write a file into a container, replacing any existing file of the same name
+func (cs *FileServiceClient) UploadFile(data *UploadFileArgs) error {
+	if _, err := validateOrid(data.Orid, orid.ServiceFile); err != nil {
+		return err
+	}
+	if data.FileName == "" {
+		return errors.New("file name is required")
+	}
+
+	client := cs.httpConfig.newClient(orid.ServiceFile, API_TIMEOUT, false)
+
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	payload, pipeWriter := io.Pipe()
+	writer := multipart.NewWriter(pipeWriter)
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/upload/%s", cs.fileServiceURL, data.Orid), payload)
+	if err != nil {
+		return errors.New("could not build request to upload file")
+	}
+
+	uploadErr := make(chan error, 1)
+	go func() {
+		err := writeUploadFileForm(writer, data)
+		pipeWriter.CloseWithError(err)
+		uploadErr <- err
+	}()
+
+	req = withOperation(cs.ctx, req, "UploadFile", data.Orid)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+
+	payload.Close()
+	if writeErr := <-uploadErr; writeErr != nil && !errors.Is(writeErr, io.ErrClosedPipe) {
+		if err == nil {
+			r.Body.Close()
+		}
+		return fmt.Errorf("could not read file source: %w", writeErr)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200, 201:
+		return nil
+	case 404:
+		return fmt.Errorf("container %s: %w", data.Orid, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+func writeUploadFileForm(writer *multipart.Writer, data *UploadFileArgs) error {
+	part, err := writer.CreateFormFile("file", data.FileName)
+	if err != nil {
+		return err
+	}
+	if data.Source != nil {
+		if _, err = io.Copy(part, data.Source); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// DownloadFile Attempts to stream the content of the file orid into w
+func (cs *FileServiceClient) DownloadFile(fileOrid string, w io.Writer) error {
+	if _, err := validateOrid(fileOrid, orid.ServiceFile); err != nil {
+		return err
+	}
+
+	client := cs.httpConfig.newClient(orid.ServiceFile, API_TIMEOUT, false)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/download/%s", cs.fileServiceURL, fileOrid), nil)
+	if err != nil {
+		return errors.New("could not build request to download file")
+	}
+
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	req = withOperation(cs.ctx, req, "DownloadFile", fileOrid)
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200:
+		_, err = io.Copy(w, r.Body)
+		return err
+	case 404:
+		return fmt.Errorf("file %s: %w", fileOrid, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// ContainerEntry A file or directory visited by WalkContainer
+//
+// Path        - Slash separated path relative to the walked orid, "." for the walked orid itself
+// HasMetadata - Whether the service reported the size, modified time, content type and checksum of the file.
+// Services that do not return metadata leave it false and those fields empty.
+type ContainerEntry struct {
+	Name        string
+	Path        string
+	Orid        string
+	IsDir       bool
+	Size        int64
+	Modified    time.Time
+	ContentType string
+	Checksum    string
+	HasMetadata bool
+}
+
+// WalkContainerFunc Called by WalkContainer for each entry. As with filepath.WalkFunc, err reports a failure to
+// list the directory at path, returning fs.SkipDir skips the directory, or the rest of the directory holding a
+// file, and returning fs.SkipAll stops the walk.
+type WalkContainerFunc func(path string, entry *ContainerEntry, err error) error
+
+// WalkContainer Walks the directory tree rooted at the container or path orid in lexical order, calling fn for
+// the root and every file and directory below it
+func (cs *FileServiceClient) WalkContainer(rootOrid string, fn WalkContainerFunc) error {
+	root, err := validateOrid(rootOrid, orid.ServiceFile)
+	if err != nil {
+		return err
+	}
+
+	name := root.ResourceID
+	if subPath := root.SubPath(); len(subPath) > 0 {
+		name = subPath[len(subPath)-1]
+	}
+
+	err = cs.walk(root, ".", &ContainerEntry{Name: name, Path: ".", Orid: root.String(), IsDir: true}, fn)
+	if err == fs.SkipDir || err == fs.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walk Mirrors the recursion of filepath.Walk over the file service
+func (cs *FileServiceClient) walk(dirOrid *orid.Orid, relPath string, entry *ContainerEntry, fn WalkContainerFunc) error {
+	if !entry.IsDir {
+		return fn(relPath, entry, nil)
+	}
+
+	var children []*ContainerEntry
+	contents, err := cs.listContainerContents(&ListContainerContentsArgs{Orid: dirOrid.String(), IncludeMetadata: true})
+	if err == nil {
+		children, err = containerEntries(contents, dirOrid, relPath)
+	}
+	fnErr := fn(relPath, entry, err)
+	if err != nil || fnErr != nil {
+		return fnErr
+	}
+
+	for _, child := range children {
+		childOrid := dirOrid.Join(child.Name)
+		if err = cs.walk(childOrid, child.Path, child, fn); err != nil {
+			if !child.IsDir || err != fs.SkipDir {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// containerEntries The files and directories of a listing sorted by name. Names that are not a single path element
+// are rejected so entry paths never leave the walked orid.
+func containerEntries(contents *ListContainerContentsResult, dirOrid *orid.Orid, relPath string) ([]*ContainerEntry, error) {
+	details := make(map[string]FileInfo, len(contents.FileDetails))
+	for _, info := range contents.FileDetails {
+		details[info.Name] = info
+	}
+
+	entries := make([]*ContainerEntry, 0, len(contents.Directories)+len(contents.Files))
+	for _, name := range contents.Directories {
+		entries = append(entries, &ContainerEntry{Name: name, IsDir: true})
+	}
+	for _, name := range contents.Files {
+		info, ok := details[name]
+		entries = append(entries, &ContainerEntry{
+			Name:        name,
+			Size:        info.Size,
+			Modified:    info.Modified,
+			ContentType: info.ContentType,
+			Checksum:    info.Checksum,
+			HasMetadata: ok,
+		})
+	}
+
+	for _, entry := range entries {
+		if entry.Name == "" || entry.Name == "." || entry.Name == ".." || strings.Contains(entry.Name, "/") {
+			return nil, fmt.Errorf("file service returned invalid name %q in %s", entry.Name, dirOrid)
+		}
+		entry.Path = path.Join(relPath, entry.Name)
+		entry.Orid = dirOrid.Join(entry.Name).String()
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
@@ -0,0 +1,176 @@
+package sdk
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdktest"
+)
+
+func createTestContainer(t *testing.T, client *FileServiceClient, srv *sdktest.Server, files map[string]string) string {
+	result, err := client.CreateContainer(&CreateContainerArgs{Name: "walk"})
+	if err != nil {
+		t.Fatalf("Unexpected error creating container: %s", err)
+	}
+	for name, content := range files {
+		srv.PutFile(result.Orid, name, []byte(content))
+	}
+	return result.Orid
+}
+
+func TestListContainerContentsMetadata(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"notes.txt": "hello", "sub/a.json": "{}"})
+
+	contents, err := client.ListContainerContents(&ListContainerContentsArgs{Orid: containerOrid, IncludeMetadata: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(contents.FileDetails), 1, "File details count incorrect")
+	info := contents.FileDetails[0]
+	assertString(t, info.Name, "notes.txt", "Name incorrect")
+	assertInt(t, int(info.Size), 5, "Size incorrect")
+	assertString(t, strings.Split(info.ContentType, ";")[0], "text/plain", "Content type incorrect")
+	if info.Modified.IsZero() {
+		t.Error("Expected modified time")
+	}
+
+	contents, err = client.ListContainerContents(&ListContainerContentsArgs{Orid: containerOrid})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(contents.FileDetails), 0, "File details should be omitted")
+}
+
+func TestListContainerContentsMissingMetadata(t *testing.T) {
+	_, srv := newTestSdk(t)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/download/") {
+			w.Write([]byte("notes"))
+			return
+		}
+		w.Write([]byte(`{"directories":[],"files":["notes.txt"]}`))
+	}))
+	defer api.Close()
+
+	urls := srv.URLs()
+	urls["fsUrl"] = api.URL
+	client := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls).
+		GetFileServiceClient()
+	containerOrid := "orid:1:mdsCloud:::1001:fs:walk"
+
+	_, err := client.ListContainerContents(&ListContainerContentsArgs{Orid: containerOrid, IncludeMetadata: true})
+	if err == nil || !strings.Contains(err.Error(), "notes.txt") {
+		t.Errorf("Expected missing metadata error, got %v", err)
+	}
+
+	contents, err := client.ListContainerContents(&ListContainerContentsArgs{Orid: containerOrid})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(contents.Files), 1, "Files count incorrect")
+
+	var files []*ContainerEntry
+	err = client.WalkContainer(containerOrid, func(path string, entry *ContainerEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir {
+			files = append(files, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error walking: %s", err)
+	}
+	assertInt(t, len(files), 1, "Walked file count incorrect")
+	if files[0].HasMetadata {
+		t.Error("Expected walked file without metadata")
+	}
+
+	dir := t.TempDir()
+	for _, expected := range []string{"add notes.txt", "update notes.txt"} {
+		result, err := client.SyncDown(containerOrid, dir, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error syncing: %s", err)
+		}
+		assertString(t, syncOperations(result), expected, "Files without metadata should always be transferred")
+	}
+}
+
+func TestWalkContainer(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{
+		"b.txt":          "b",
+		"a/one.txt":      "1",
+		"a/deep/two.txt": "22",
+		"c/skip.txt":     "skip",
+	})
+
+	visited := make([]string, 0)
+	sizes := make(map[string]int64)
+	err := client.WalkContainer(containerOrid, func(path string, entry *ContainerEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		visited = append(visited, path)
+		sizes[path] = entry.Size
+		if !entry.IsDir && !entry.HasMetadata {
+			t.Errorf("Expected metadata for %s", path)
+		}
+		if entry.IsDir && entry.Name == "c" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, strings.Join(visited, ","), ".,a,a/deep,a/deep/two.txt,a/one.txt,b.txt,c", "Visited paths incorrect")
+	assertInt(t, int(sizes["a/deep/two.txt"]), 2, "Size incorrect")
+
+	visited = visited[:0]
+	err = client.WalkContainer(containerOrid+"/a", func(path string, entry *ContainerEntry, err error) error {
+		visited = append(visited, path+"="+entry.Orid[strings.LastIndex(entry.Orid, ":")+1:])
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, strings.Join(visited, ","), ".=walk/a,deep=walk/a/deep,deep/two.txt=walk/a/deep/two.txt,one.txt=walk/a/one.txt", "Sub path walk incorrect")
+}
+
+func TestWalkContainerStopsAndReportsErrors(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetFileServiceClient()
+	containerOrid := createTestContainer(t, client, srv, map[string]string{"a.txt": "a", "b.txt": "b"})
+
+	count := 0
+	err := client.WalkContainer(containerOrid, func(path string, entry *ContainerEntry, err error) error {
+		count++
+		if path == "a.txt" {
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, count, 2, "Visit count incorrect")
+
+	var listErr error
+	boom := errors.New("boom")
+	err = client.WalkContainer(containerOrid+"/missing", func(path string, entry *ContainerEntry, err error) error {
+		listErr = err
+		return boom
+	})
+	if listErr == nil || err != boom {
+		t.Errorf("Expected listing error passed to fn and fn error returned, got %v and %v", listErr, err)
+	}
+}
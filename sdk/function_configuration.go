@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// UpdateFunctionConfigurationArgs Configuration to change on a function without uploading its code. Empty values
+// leave the current configuration in place.
+//
+// Context     - Context passed to the function. Use "NULL" to clear it.
+// Environment - Environment variables, replacing the current set when not nil. Use an empty map to clear them.
+// MemorySize  - Memory limit in megabytes
+// Timeout     - Execution time limit, rounded up to whole seconds
+// Tags        - Tags, replacing the current set when not nil. Use an empty map to clear them.
+type UpdateFunctionConfigurationArgs struct {
+	Orid        string
+	Runtime     string
+	EntryPoint  string
+	Context     string
+	Environment map[string]string
+	MemorySize  int
+	Timeout     time.Duration
+	Tags        map[string]string
+}
+
+// buildFunctionConfigurationPayload Converts the arguments into the request body, omitting unchanged values
+func buildFunctionConfigurationPayload(data *UpdateFunctionConfigurationArgs) (map[string]interface{}, error) {
+	if data.MemorySize < 0 {
+		return nil, errors.New("memory size must not be negative")
+	}
+	if data.Timeout < 0 {
+		return nil, errors.New("timeout must not be negative")
+	}
+
+	payload := make(map[string]interface{})
+	if data.Runtime != "" {
+		payload["runtime"] = data.Runtime
+	}
+	if data.EntryPoint != "" {
+		payload["entryPoint"] = data.EntryPoint
+	}
+	if data.Context == "NULL" {
+		payload["context"] = ""
+	} else if data.Context != "" {
+		payload["context"] = data.Context
+	}
+	if data.Environment != nil {
+		payload["environment"] = data.Environment
+	}
+	if data.MemorySize > 0 {
+		payload["memorySize"] = data.MemorySize
+	}
+	if data.Timeout > 0 {
+		payload["timeoutSeconds"] = int((data.Timeout + time.Second - 1) / time.Second)
+	}
+	if data.Tags != nil {
+		payload["tags"] = data.Tags
+	}
+
+	if len(payload) == 0 {
+		return nil, errors.New("no configuration changes requested")
+	}
+	return payload, nil
+}
+
+// UpdateFunctionConfiguration Changes the configuration of a function without rebuilding its code
+func (c *ServerlessFunctionsClient) UpdateFunctionConfiguration(data *UpdateFunctionConfigurationArgs) error {
+	if _, err := validateOrid(data.Orid, orid.ServiceServerlessFunctions); err != nil {
+		return err
+	}
+
+	payload, err := buildFunctionConfigurationPayload(data)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return errors.New("could not acquire authentication token")
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/configuration/%s", c.serviceURL, data.Orid), bytes.NewReader(body))
+	if err != nil {
+		return errors.New("could not build request to update function configuration")
+	}
+
+	req = withOperation(c.ctx, req, "UpdateFunctionConfiguration", data.Orid)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not execute request to update function configuration: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200:
+		return nil
+	case 400:
+		body, _ := io.ReadAll(r.Body)
+		return errors.New(string(body))
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
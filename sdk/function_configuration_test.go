@@ -0,0 +1,55 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildFunctionConfigurationPayload(t *testing.T) {
+	payload, err := buildFunctionConfigurationPayload(&UpdateFunctionConfigurationArgs{
+		Context:     "NULL",
+		Environment: map[string]string{},
+		Timeout:     1500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(payload), 3, "Payload size incorrect")
+	assertString(t, payload["context"].(string), "", "Context should be cleared")
+	assertInt(t, payload["timeoutSeconds"].(int), 2, "Timeout should round up")
+
+	if _, err = buildFunctionConfigurationPayload(&UpdateFunctionConfigurationArgs{}); err == nil {
+		t.Error("Expected error without changes")
+	}
+	if _, err = buildFunctionConfigurationPayload(&UpdateFunctionConfigurationArgs{MemorySize: -1}); err == nil {
+		t.Error("Expected error for negative memory size")
+	}
+}
+
+func TestUpdateFunctionConfiguration(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	err := client.UpdateFunctionConfiguration(&UpdateFunctionConfigurationArgs{
+		Orid:        functionOrid,
+		EntryPoint:  "src/two:main",
+		Environment: map[string]string{"LEVEL": "debug"},
+		MemorySize:  256,
+		Timeout:     time.Minute,
+		Tags:        map[string]string{"team": "core"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	function := srv.Functions()[0]
+	assertString(t, function.Runtime, "node", "Runtime should be unchanged")
+	assertString(t, function.EntryPoint, "src/two:main", "Entry point incorrect")
+	assertString(t, function.Environment["LEVEL"], "debug", "Environment incorrect")
+	assertInt(t, function.MemorySize, 256, "Memory size incorrect")
+	assertInt(t, function.TimeoutSeconds, 60, "Timeout incorrect")
+	assertString(t, function.Tags["team"], "core", "Tags incorrect")
+	assertInt(t, function.Version, 1, "Configuration changes should not rebuild code")
+}
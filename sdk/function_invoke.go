@@ -0,0 +1,363 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// Headers carrying invocation metadata in serverless function responses
+const (
+	InvocationIDHeader    = "X-Mds-Invocation-Id"
+	FunctionVersionHeader = "X-Mds-Function-Version"
+	DurationHeader        = "X-Mds-Duration-Ms"
+	LogsHeader            = "X-Mds-Logs"
+	FunctionErrorHeader   = "X-Mds-Function-Error"
+)
+
+// InvocationMetadata Execution details reported by the service alongside an invocation result. Fields the
+// service does not send are left empty.
+//
+// Logs - Output the function wrote while running, sent base64 encoded in the X-Mds-Logs header
+type InvocationMetadata struct {
+	InvocationID string
+	Version      string
+	Duration     time.Duration
+	Logs         string
+}
+
+// FunctionError Returned when the function itself failed, as opposed to the request not reaching it
+type FunctionError struct {
+	Orid     string
+	Type     string
+	Message  string
+	Metadata *InvocationMetadata
+}
+
+func (e *FunctionError) Error() string {
+	return e.Message
+}
+
+// InvocationDecodeError Returned when a successful invocation result could not be decoded into the output value
+type InvocationDecodeError struct {
+	Orid    string
+	Payload []byte
+	Err     error
+}
+
+func (e *InvocationDecodeError) Error() string {
+	return fmt.Sprintf("could not decode result of function %s: %s", e.Orid, e.Err)
+}
+
+func (e *InvocationDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// parseInvocationMetadata Reads the invocation metadata headers of a response
+func parseInvocationMetadata(header http.Header) *InvocationMetadata {
+	metadata := &InvocationMetadata{
+		InvocationID: header.Get(InvocationIDHeader),
+		Version:      header.Get(FunctionVersionHeader),
+	}
+	if millis, err := strconv.ParseInt(header.Get(DurationHeader), 10, 64); err == nil {
+		metadata.Duration = time.Duration(millis) * time.Millisecond
+	}
+	if logs, err := base64.StdEncoding.DecodeString(header.Get(LogsHeader)); err == nil {
+		metadata.Logs = string(logs)
+	}
+	return metadata
+}
+
+// invoke Sends the input to the function, or the version or alias named by the qualifier, returning the raw
+// result. Failures of the function itself are returned as a *FunctionError.
+func (c *ServerlessFunctionsClient) invoke(functionOrid string, qualifier string, input interface{}) ([]byte, *InvocationMetadata, error) {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return nil, nil, err
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, 30*time.Minute, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return nil, nil, errors.New("could not acquire authentication token")
+	}
+
+	bodyBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	invokeURL := fmt.Sprintf("%s/v1/invoke/%s", c.serviceURL, functionOrid)
+	if qualifier != "" {
+		invokeURL += "?qualifier=" + url.QueryEscape(qualifier)
+	}
+	payload := bytes.NewReader(bodyBytes)
+	req, err := http.NewRequest("POST", invokeURL, payload)
+	if err != nil {
+		return nil, nil, errors.New("could not build request to invoke function")
+	}
+
+	req = withOperation(c.ctx, req, "InvokeFunction", functionOrid)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not execute request to invoke function: %w", err)
+	}
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read result of function: %w", err)
+	}
+	metadata := parseInvocationMetadata(r.Header)
+
+	switch {
+	case r.Header.Get(FunctionErrorHeader) != "":
+		return nil, metadata, &FunctionError{
+			Orid:     functionOrid,
+			Type:     r.Header.Get(FunctionErrorHeader),
+			Message:  string(body),
+			Metadata: metadata,
+		}
+	case r.StatusCode == 200:
+		return body, metadata, nil
+	case r.StatusCode == 400:
+		return nil, metadata, errors.New(string(body))
+	case r.StatusCode == 404 && qualifier != "":
+		return nil, metadata, fmt.Errorf("version or alias %s of function %s: %w", qualifier, functionOrid, ErrNotFound)
+	default:
+		return nil, metadata, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// InvokeFunctionInto Invokes the function with the JSON encoded input and decodes the JSON result into out, which
+// must be a pointer or nil to discard the result. Failures of the function itself are returned as a
+// *FunctionError and undecodable results as an *InvocationDecodeError.
+func (c *ServerlessFunctionsClient) InvokeFunctionInto(functionOrid string, input interface{}, out interface{}) (*InvocationMetadata, error) {
+	return c.InvokeFunctionVersion(functionOrid, "", input, out)
+}
+
+// InvokeFunctionVersion Behaves like InvokeFunctionInto but runs the version, given by number or alias, named by
+// the qualifier. An empty qualifier runs the latest version.
+func (c *ServerlessFunctionsClient) InvokeFunctionVersion(functionOrid string, qualifier string, input interface{}, out interface{}) (*InvocationMetadata, error) {
+	result, metadata, err := c.invoke(functionOrid, qualifier, input)
+	if err != nil {
+		return metadata, err
+	}
+
+	if out != nil && len(result) > 0 {
+		if err = json.Unmarshal(result, out); err != nil {
+			return metadata, &InvocationDecodeError{Orid: functionOrid, Payload: result, Err: err}
+		}
+	}
+	return metadata, nil
+}
+
+// InvokeFunctionAs Invokes the function and decodes its JSON result into a new T
+//
+//	greeting, _, err := sdk.InvokeFunctionAs[Greeting](client.WithContext(ctx), functionOrid, input)
+func InvokeFunctionAs[T any](client ServerlessFunctionsAPI, functionOrid string, input interface{}) (T, *InvocationMetadata, error) {
+	var out T
+	metadata, err := client.InvokeFunctionInto(functionOrid, input, &out)
+	return out, metadata, err
+}
+
+// Statuses of an asynchronous invocation
+const (
+	InvocationPending   = "pending"
+	InvocationSucceeded = "succeeded"
+	InvocationFailed    = "failed"
+)
+
+// InvocationResult State of an asynchronous invocation
+//
+// Result - JSON result of the function once the invocation succeeded
+// Error  - Failure of the function once the invocation failed
+type InvocationResult struct {
+	InvocationID string
+	Orid         string
+	Status       string
+	Result       json.RawMessage
+	Error        *FunctionError
+	Metadata     *InvocationMetadata
+}
+
+// Done Reports whether the invocation finished, successfully or not
+func (r *InvocationResult) Done() bool {
+	return r.Status == InvocationSucceeded || r.Status == InvocationFailed
+}
+
+// Decode Decodes the JSON result of a successful invocation into out
+func (r *InvocationResult) Decode(out interface{}) error {
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.Status != InvocationSucceeded {
+		return fmt.Errorf("invocation %s has not completed", r.InvocationID)
+	}
+	if len(r.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.Result, out); err != nil {
+		return &InvocationDecodeError{Orid: r.Orid, Payload: r.Result, Err: err}
+	}
+	return nil
+}
+
+// InvokeFunctionAsync Starts invoking the function with the JSON encoded input, returning the invocation ID
+// without waiting for the function to finish
+func (c *ServerlessFunctionsClient) InvokeFunctionAsync(functionOrid string, input interface{}) (string, error) {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return "", err
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return "", errors.New("could not acquire authentication token")
+	}
+
+	bodyBytes, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	payload := bytes.NewReader(bodyBytes)
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/invoke/%s?async=true", c.serviceURL, functionOrid), payload)
+	if err != nil {
+		return "", errors.New("could not build request to invoke function")
+	}
+
+	req = withOperation(c.ctx, req, "InvokeFunctionAsync", functionOrid)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not execute request to invoke function: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 202:
+		result := struct {
+			InvocationID string `json:"invocationId"`
+		}{}
+		err = json.NewDecoder(r.Body).Decode(&result)
+		if err != nil || result.InvocationID == "" {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return "", errors.New("could not decode response from API of resource")
+		}
+		return result.InvocationID, nil
+	case 400:
+		body, _ := io.ReadAll(r.Body)
+		return "", errors.New(string(body))
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return "", fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// GetInvocationResult Gets the current state of an asynchronous invocation
+func (c *ServerlessFunctionsClient) GetInvocationResult(invocationID string) (*InvocationResult, error) {
+	if invocationID == "" {
+		return nil, errors.New("invocation id is required")
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return nil, errors.New("could not acquire authentication token")
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/invocations/%s", c.serviceURL, url.PathEscape(invocationID)), nil)
+	if err != nil {
+		return nil, errors.New("could not build request to fetch invocation")
+	}
+
+	req = withOperation(c.ctx, req, "GetInvocationResult", "")
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request to fetch invocation: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200:
+		body := struct {
+			InvocationID string          `json:"invocationId"`
+			Orid         string          `json:"orid"`
+			Status       string          `json:"status"`
+			Version      string          `json:"version"`
+			DurationMs   int64           `json:"durationMs"`
+			Logs         string          `json:"logs"`
+			Result       json.RawMessage `json:"result"`
+			Error        *string         `json:"error"`
+		}{}
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return nil, errors.New("could not decode response from API of resource")
+		}
+		switch body.Status {
+		case InvocationPending, InvocationSucceeded, InvocationFailed:
+		default:
+			return nil, fmt.Errorf("did not understand invocation status from API: %q", body.Status)
+		}
+
+		result := &InvocationResult{
+			InvocationID: body.InvocationID,
+			Orid:         body.Orid,
+			Status:       body.Status,
+			Result:       body.Result,
+			Metadata: &InvocationMetadata{
+				InvocationID: body.InvocationID,
+				Version:      body.Version,
+				Duration:     time.Duration(body.DurationMs) * time.Millisecond,
+				Logs:         body.Logs,
+			},
+		}
+		if body.Status == InvocationFailed {
+			message := ""
+			if body.Error != nil {
+				message = *body.Error
+			}
+			result.Error = &FunctionError{Orid: body.Orid, Type: "Unhandled", Message: message, Metadata: result.Metadata}
+		}
+		return result, nil
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// WaitForInvocation Polls an asynchronous invocation until it finishes, or the client's context, see WithContext,
+// ends. When the function failed the result is returned along with its *FunctionError.
+func (c *ServerlessFunctionsClient) WaitForInvocation(invocationID string, opts *WaitOptions) (*InvocationResult, error) {
+	var result *InvocationResult
+	err := poll(c.ctx, opts, func() (bool, error) {
+		var err error
+		result, err = c.GetInvocationResult(invocationID)
+		if err != nil {
+			return false, err
+		}
+		return result.Done(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return result, result.Error
+	}
+	return result, nil
+}
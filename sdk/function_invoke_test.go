@@ -0,0 +1,231 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdktest"
+)
+
+type greeting struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func TestInvokeFunctionInto(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvocationLogs("said hello\n")
+	srv.SetInvokeHandler(func(function sdktest.Function, body []byte) ([]byte, error) {
+		input := greeting{}
+		json.Unmarshal(body, &input)
+		return json.Marshal(greeting{Name: input.Name, Message: "hello " + input.Name})
+	})
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	out := greeting{}
+	metadata, err := client.InvokeFunctionInto(functionOrid, greeting{Name: "Frito"}, &out)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, out.Message, "hello Frito", "Message incorrect")
+	assertString(t, metadata.Logs, "said hello\n", "Logs incorrect")
+	assertString(t, metadata.Version, "1", "Version incorrect")
+	if metadata.InvocationID == "" {
+		t.Error("Expected invocation id")
+	}
+}
+
+func TestInvokeFunctionAs(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	out, _, err := InvokeFunctionAs[greeting](client, functionOrid, greeting{Name: "Frito"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, out.Name, "Frito", "Name incorrect")
+}
+
+func TestInvokeFunctionIntoFunctionError(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvocationLogs("stack trace")
+	srv.SetInvokeHandler(func(function sdktest.Function, body []byte) ([]byte, error) {
+		return nil, errors.New("boom")
+	})
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	_, err := client.InvokeFunctionInto(functionOrid, nil, nil)
+	functionErr := &FunctionError{}
+	if !errors.As(err, &functionErr) {
+		t.Fatalf("Expected function error, got %v", err)
+	}
+	assertString(t, functionErr.Message, "boom", "Message incorrect")
+	assertString(t, functionErr.Type, "Unhandled", "Type incorrect")
+	assertString(t, functionErr.Metadata.Logs, "stack trace", "Logs incorrect")
+}
+
+func TestInvokeFunctionIntoRequestErrors(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+
+	_, err := client.InvokeFunctionInto(functionOrid, nil, nil)
+	if err == nil || errors.As(err, new(*FunctionError)) {
+		t.Errorf("Expected a non function error for an unbuilt function, got %v", err)
+	}
+
+	uploadTestFunction(t, client, functionOrid)
+	out := 0
+	_, err = client.InvokeFunctionInto(functionOrid, greeting{Name: "Frito"}, &out)
+	if !errors.As(err, new(*InvocationDecodeError)) {
+		t.Errorf("Expected decode error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.WithContext(ctx).InvokeFunctionInto(functionOrid, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation error, got %v", err)
+	}
+}
+
+func TestInvokeFunctionAsync(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvocationDelay(30 * time.Millisecond)
+	srv.SetInvocationLogs("done")
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	invocationID, err := client.InvokeFunctionAsync(functionOrid, greeting{Name: "Frito"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	pending, err := client.GetInvocationResult(invocationID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, pending.Status, InvocationPending, "Status incorrect")
+
+	result, err := client.WaitForInvocation(invocationID, fastWait)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, result.Status, InvocationSucceeded, "Status incorrect")
+	assertString(t, result.Orid, functionOrid, "Orid incorrect")
+	assertString(t, result.Metadata.Logs, "done", "Logs incorrect")
+
+	out := greeting{}
+	if err = result.Decode(&out); err != nil {
+		t.Fatalf("Unexpected error decoding: %s", err)
+	}
+	assertString(t, out.Name, "Frito", "Name incorrect")
+}
+
+func TestWaitForInvocationFunctionError(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvokeHandler(func(function sdktest.Function, body []byte) ([]byte, error) {
+		return nil, errors.New("boom")
+	})
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	invocationID, err := client.InvokeFunctionAsync(functionOrid, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	result, err := client.WaitForInvocation(invocationID, fastWait)
+	functionErr := &FunctionError{}
+	if !errors.As(err, &functionErr) {
+		t.Fatalf("Expected function error, got %v", err)
+	}
+	assertString(t, functionErr.Message, "boom", "Message incorrect")
+	assertString(t, result.Status, InvocationFailed, "Status incorrect")
+}
+
+func TestWaitForInvocationHonorsContext(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvocationDelay(time.Hour)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	invocationID, err := client.InvokeFunctionAsync(functionOrid, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.WithContext(ctx).WaitForInvocation(invocationID, fastWait)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	_, err = client.GetInvocationResult("missing")
+	if err == nil {
+		t.Error("Expected error for unknown invocation")
+	}
+}
+
+func TestWaitForInvocationRejectsUnknownStatus(t *testing.T) {
+	_, srv := newTestSdk(t)
+	for _, status := range []string{"", "exploded"} {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]string{"invocationId": "abc", "status": status})
+		}))
+		defer api.Close()
+
+		urls := srv.URLs()
+		urls["sfUrl"] = api.URL
+		client := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls).
+			GetServerlessFunctionsClient()
+
+		result, err := client.WaitForInvocation("abc", fastWait)
+		if err == nil || !strings.Contains(err.Error(), "invocation status") {
+			t.Errorf("Expected unknown status error for %q, got %v (%v)", status, err, result)
+		}
+	}
+
+	if (&InvocationResult{Status: "exploded"}).Done() {
+		t.Error("Expected unknown status not to be done")
+	}
+}
+
+func TestServerCloseStopsPendingInvocations(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvocationDelay(time.Hour)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	if _, err := client.InvokeFunctionAsync(functionOrid, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		srv.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Close to stop the pending invocation")
+	}
+}
@@ -0,0 +1,179 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// ListFunctionsOptions Filters and page size for listing functions
+//
+// NamePrefix - Only list functions whose name starts with the prefix
+// Runtime    - Only list functions built for the runtime
+// PageSize   - Functions requested per page. Zero lets the service decide.
+type ListFunctionsOptions struct {
+	NamePrefix string
+	Runtime    string
+	PageSize   int
+}
+
+// FunctionPage A page of functions. NextToken is empty on the last page.
+type FunctionPage struct {
+	Functions []ServerlessFunctionSummary
+	NextToken string
+}
+
+// functionSummaryPayload Function summary as returned by the serverless functions service
+type functionSummaryPayload struct {
+	Orid    string `json:"orid"`
+	Name    string `json:"name"`
+	Runtime string `json:"runtime"`
+}
+
+// decodeFunctionPage Decodes either a page of functions or, from services without pagination, a bare list
+func decodeFunctionPage(body []byte) ([]functionSummaryPayload, string, error) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		rows := make([]functionSummaryPayload, 0)
+		err := json.Unmarshal(body, &rows)
+		return rows, "", err
+	}
+
+	page := struct {
+		Functions []functionSummaryPayload `json:"functions"`
+		NextToken string                   `json:"nextToken"`
+	}{}
+	err := json.Unmarshal(body, &page)
+	return page.Functions, page.NextToken, err
+}
+
+// matches Reports whether the function passes the filters. Applied client side as well in case the service
+// ignores them. Rows without a runtime, as listed by services that do not report one, are left to the service's
+// runtime filter.
+func (o *ListFunctionsOptions) matches(f *ServerlessFunctionSummary) bool {
+	if !strings.HasPrefix(f.Name, o.NamePrefix) {
+		return false
+	}
+	return o.Runtime == "" || f.Runtime == "" || f.Runtime == o.Runtime
+}
+
+// ListFunctionsPage Fetches a single page of functions. Pass the NextToken of the previous page, or an empty
+// token for the first page.
+func (c *ServerlessFunctionsClient) ListFunctionsPage(opts *ListFunctionsOptions, pageToken string) (*FunctionPage, error) {
+	if opts == nil {
+		opts = &ListFunctionsOptions{}
+	}
+	if opts.PageSize < 0 {
+		return nil, errors.New("page size must not be negative")
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return nil, errors.New("could not acquire authentication token")
+	}
+
+	query := url.Values{}
+	if opts.NamePrefix != "" {
+		query.Set("namePrefix", opts.NamePrefix)
+	}
+	if opts.Runtime != "" {
+		query.Set("runtime", opts.Runtime)
+	}
+	if opts.PageSize > 0 {
+		query.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	if pageToken != "" {
+		query.Set("nextToken", pageToken)
+	}
+	listURL := fmt.Sprintf("%s/v1/list", c.serviceURL)
+	if len(query) > 0 {
+		listURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest("GET", listURL, nil)
+	if err != nil {
+		return nil, errors.New("could not build request to fetch list of functions from API")
+	}
+
+	req = withOperation(c.ctx, req, "ListFunctions", "")
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request to fetch list of functions from serverless functions API: %w", err)
+	}
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read list of functions: %w", err)
+	}
+
+	switch r.StatusCode {
+	case 200:
+		rows, nextToken, err := decodeFunctionPage(body)
+		if err != nil {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return nil, fmt.Errorf("could not decode response from API of resource: %w", err)
+		}
+
+		page := &FunctionPage{Functions: make([]ServerlessFunctionSummary, 0, len(rows)), NextToken: nextToken}
+		for i, row := range rows {
+			if row.Orid == "" || row.Name == "" {
+				return nil, fmt.Errorf("could not decode response from API of resource: function %d is missing its orid or name", i)
+			}
+
+			summary := ServerlessFunctionSummary{Orid: row.Orid, Name: row.Name, Runtime: row.Runtime}
+			if opts.matches(&summary) {
+				page.Functions = append(page.Functions, summary)
+			}
+		}
+		return page, nil
+	case 400:
+		return nil, errors.New(string(body))
+	default:
+		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// IterateFunctions Yields every function matching the options, fetching pages as needed. Iteration stops after
+// yielding an error.
+//
+//	for function, err := range client.WithContext(ctx).IterateFunctions(&sdk.ListFunctionsOptions{NamePrefix: "billing-"}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(function.Name)
+//	}
+func (c *ServerlessFunctionsClient) IterateFunctions(opts *ListFunctionsOptions) iter.Seq2[ServerlessFunctionSummary, error] {
+	return func(yield func(ServerlessFunctionSummary, error) bool) {
+		pageToken := ""
+		for {
+			page, err := c.ListFunctionsPage(opts, pageToken)
+			if err != nil {
+				yield(ServerlessFunctionSummary{}, err)
+				return
+			}
+
+			for _, function := range page.Functions {
+				if !yield(function, nil) {
+					return
+				}
+			}
+
+			if page.NextToken == "" || page.NextToken == pageToken {
+				return
+			}
+			pageToken = page.NextToken
+		}
+	}
+}
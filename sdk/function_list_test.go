@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdktest"
+)
+
+func TestIterateFunctionsPagesAndFilters(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	for _, name := range []string{"billing-a", "billing-b", "billing-c", "reports"} {
+		summary, err := client.CreateFunction(name)
+		if err != nil {
+			t.Fatalf("Unexpected error creating function: %s", err)
+		}
+		if name != "billing-b" {
+			uploadTestFunction(t, client, summary.Orid)
+		}
+	}
+
+	names := make([]string, 0)
+	for function, err := range client.IterateFunctions(&ListFunctionsOptions{NamePrefix: "billing-", PageSize: 1}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		names = append(names, function.Name)
+	}
+	assertString(t, strings.Join(names, ","), "billing-a,billing-b,billing-c", "Prefix filtered names incorrect")
+
+	names = names[:0]
+	for function, err := range client.IterateFunctions(&ListFunctionsOptions{Runtime: "node", PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		names = append(names, function.Name)
+	}
+	assertString(t, strings.Join(names, ","), "billing-a,billing-c,reports", "Runtime filtered names incorrect")
+
+	page, err := client.ListFunctionsPage(&ListFunctionsOptions{PageSize: 3}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(page.Functions), 3, "Page size incorrect")
+	assertString(t, page.NextToken, "3", "Next token incorrect")
+
+	all, err := client.ListFunctions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(*all), 4, "Function count incorrect")
+}
+
+func TestListFunctionsReturnsErrors(t *testing.T) {
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		"missing name": {200, `[{"orid":"orid:1:mdsCloud:::1001:sf:abc"}]`},
+		"wrong type":   {200, `[{"orid":"orid:1:mdsCloud:::1001:sf:abc","name":5}]`},
+		"server error": {500, `oops`},
+	}
+
+	for name, response := range responses {
+		t.Run(name, func(t *testing.T) {
+			_, srv := newTestSdk(t)
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(response.status)
+				w.Write([]byte(response.body))
+			}))
+			defer api.Close()
+
+			urls := srv.URLs()
+			urls["sfUrl"] = api.URL
+			sdk := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls)
+
+			if _, err := sdk.GetServerlessFunctionsClient().ListFunctions(); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestListFunctionsPageTrustsRuntimeFilterWithoutRuntimes(t *testing.T) {
+	_, srv := newTestSdk(t)
+	queries := make([]string, 0)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(`[{"orid":"orid:1:mdsCloud:::1001:sf:abc","name":"billing"}]`))
+	}))
+	defer api.Close()
+
+	urls := srv.URLs()
+	urls["sfUrl"] = api.URL
+	sdk := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls)
+
+	page, err := sdk.GetServerlessFunctionsClient().ListFunctionsPage(&ListFunctionsOptions{Runtime: "node"}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(page.Functions), 1, "Functions without a runtime should not be filtered out")
+	assertString(t, queries[0], "runtime=node", "Runtime filter should be sent to the service")
+}
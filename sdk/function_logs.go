@@ -0,0 +1,198 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// tailLogPageSize Log entries requested per poll while tailing
+const tailLogPageSize = 500
+
+// FunctionLogEntry A line written by a function while running
+type FunctionLogEntry struct {
+	ID           string
+	InvocationID string
+	Timestamp    time.Time
+	Message      string
+}
+
+// GetFunctionLogs Gets the oldest log entries written by the function at or after since, up to limit entries.
+// A zero since returns entries from the start of the retained logs and a zero limit lets the service decide.
+func (c *ServerlessFunctionsClient) GetFunctionLogs(functionOrid string, since time.Time, limit int) ([]FunctionLogEntry, error) {
+	return c.getFunctionLogs(functionOrid, since, 0, limit)
+}
+
+// getFunctionLogs Behaves like GetFunctionLogs but skips the first offset entries at or after since
+func (c *ServerlessFunctionsClient) getFunctionLogs(functionOrid string, since time.Time, offset int, limit int) ([]FunctionLogEntry, error) {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return nil, errors.New("could not acquire authentication token")
+	}
+
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339Nano))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	logsURL := fmt.Sprintf("%s/v1/logs/%s", c.serviceURL, functionOrid)
+	if len(query) > 0 {
+		logsURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest("GET", logsURL, nil)
+	if err != nil {
+		return nil, errors.New("could not build request to fetch function logs from API")
+	}
+
+	req = withOperation(c.ctx, req, "GetFunctionLogs", functionOrid)
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request to fetch function logs: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200:
+		payload := make([]struct {
+			ID           string `json:"id"`
+			InvocationID string `json:"invocationId"`
+			Timestamp    string `json:"timestamp"`
+			Message      string `json:"message"`
+		}, 0)
+		err = json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return nil, errors.New("could not decode response from API of resource")
+		}
+
+		entries := make([]FunctionLogEntry, 0, len(payload))
+		for _, p := range payload {
+			timestamp, err := parseFunctionTime(p.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode response from API of resource: %w", err)
+			}
+			entries = append(entries, FunctionLogEntry{
+				ID:           p.ID,
+				InvocationID: p.InvocationID,
+				Timestamp:    timestamp,
+				Message:      p.Message,
+			})
+		}
+		return entries, nil
+	case 400:
+		body, _ := io.ReadAll(r.Body)
+		return nil, errors.New(string(body))
+	case 404:
+		return nil, fmt.Errorf("function %s: %w", functionOrid, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// TailFunctionLogs Yields the log entries written by the function at or after since, then keeps polling for new
+// entries until the client context ends. Polls back off while the function is quiet and speed up again once it
+// logs. Iteration stops quietly when the context ends and after yielding any other error.
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	for entry, err := range client.WithContext(ctx).TailFunctionLogs(functionOrid, time.Now(), nil) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(entry.Timestamp, entry.Message)
+//	}
+func (c *ServerlessFunctionsClient) TailFunctionLogs(functionOrid string, since time.Time, opts *WaitOptions) iter.Seq2[FunctionLogEntry, error] {
+	return func(yield func(FunctionLogEntry, error) bool) {
+		ctx := c.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		settings := opts.withDefaults()
+		interval := settings.InitialInterval
+
+		// Entries at the cursor were already yielded, so the next poll skips past them. Many entries can share a
+		// timestamp, so moving the cursor alone would not get past a full page of them. Entries are told apart by
+		// their ID, or by their position at the cursor when the service does not give one.
+		cursor := since
+		seen := make(map[string]bool)
+
+		for {
+			entries, err := c.getFunctionLogs(functionOrid, cursor, len(seen), tailLogPageSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					yield(FunctionLogEntry{}, err)
+				}
+				return
+			}
+
+			fresh := 0
+			for _, entry := range entries {
+				if entry.Timestamp.After(cursor) {
+					cursor = entry.Timestamp
+					seen = make(map[string]bool)
+				}
+				key := "id " + entry.ID
+				if entry.ID == "" {
+					key = "position " + strconv.Itoa(len(seen))
+				}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				fresh++
+
+				if !yield(entry, nil) {
+					return
+				}
+			}
+
+			if fresh > 0 && len(entries) == tailLogPageSize {
+				continue
+			}
+			if fresh > 0 {
+				interval = settings.InitialInterval
+			}
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			if fresh == 0 {
+				interval = time.Duration(float64(interval) * settings.Multiplier)
+				if interval > settings.MaxInterval {
+					interval = settings.MaxInterval
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdktest"
+)
+
+func TestGetFunctionLogs(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvocationLogs("starting\nfinished\n")
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	metadata, err := client.InvokeFunctionInto(functionOrid, nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error invoking: %s", err)
+	}
+
+	entries, err := client.GetFunctionLogs(functionOrid, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(entries), 2, "Entry count incorrect")
+	assertString(t, entries[0].Message, "starting", "Message incorrect")
+	assertString(t, entries[0].InvocationID, metadata.InvocationID, "Invocation id incorrect")
+
+	entries, err = client.GetFunctionLogs(functionOrid, time.Time{}, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(entries), 1, "Limited entry count incorrect")
+
+	entries, err = client.GetFunctionLogs(functionOrid, time.Now().Add(time.Minute), 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertInt(t, len(entries), 0, "Entries after since incorrect")
+
+	client.DeleteFunction(functionOrid)
+	if _, err = client.GetFunctionLogs(functionOrid, time.Time{}, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTailFunctionLogs(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	srv.AddFunctionLog(functionOrid, "one")
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		srv.AddFunctionLog(functionOrid, "two")
+		time.Sleep(30 * time.Millisecond)
+		srv.AddFunctionLog(functionOrid, "three")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages := make([]string, 0)
+	for entry, err := range client.WithContext(ctx).TailFunctionLogs(functionOrid, time.Time{}, fastWait) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		messages = append(messages, entry.Message)
+		if len(messages) == 3 {
+			break
+		}
+	}
+	assertString(t, strings.Join(messages, ","), "one,two,three", "Tailed messages incorrect")
+}
+
+func TestTailFunctionLogsPagesThroughSharedTimestamps(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+
+	// Lines added together share a timestamp, so these fill more than two pages at a single instant
+	lines := make([]string, 0, 2*tailLogPageSize+10)
+	for i := 0; i < cap(lines); i++ {
+		lines = append(lines, strconv.Itoa(i))
+	}
+	srv.AddFunctionLog(functionOrid, strings.Join(lines, "\n"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seen := make(map[string]bool)
+	for entry, err := range client.WithContext(ctx).TailFunctionLogs(functionOrid, time.Time{}, fastWait) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if seen[entry.ID] {
+			t.Fatalf("Entry %s yielded twice", entry.ID)
+		}
+		seen[entry.ID] = true
+		if len(seen) == len(lines) {
+			break
+		}
+	}
+	assertInt(t, len(seen), len(lines), "Tailed entry count incorrect")
+}
+
+func TestTailFunctionLogsWithoutIDs(t *testing.T) {
+	_, srv := newTestSdk(t)
+	urls := srv.URLs()
+	target, _ := url.Parse(urls["sfUrl"])
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.ModifyResponse = func(r *http.Response) error {
+		if !strings.Contains(r.Request.URL.Path, "/v1/logs/") || r.StatusCode != 200 {
+			return nil
+		}
+		entries := make([]map[string]string, 0)
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			return err
+		}
+		r.Body.Close()
+		for _, entry := range entries {
+			delete(entry, "id")
+		}
+		body, _ := json.Marshal(entries)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		return nil
+	}
+	api := httptest.NewServer(proxy)
+	defer api.Close()
+
+	urls["sfUrl"] = api.URL
+	client := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls).
+		GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	srv.AddFunctionLog(functionOrid, "one\ntwo")
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		srv.AddFunctionLog(functionOrid, "three")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages := make([]string, 0)
+	for entry, err := range client.WithContext(ctx).TailFunctionLogs(functionOrid, time.Time{}, fastWait) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		messages = append(messages, entry.Message)
+		if len(messages) == 3 {
+			break
+		}
+	}
+	assertString(t, strings.Join(messages, ","), "one,two,three", "Tailed messages incorrect")
+}
+
+func TestTailFunctionLogsStopsWithContext(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	for _, err := range client.WithContext(ctx).TailFunctionLogs(functionOrid, time.Time{}, fastWait) {
+		t.Fatalf("Unexpected entry or error: %v", err)
+	}
+}
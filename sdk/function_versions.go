@@ -0,0 +1,279 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// FunctionVersion Code published by an upload of a function's code
+type FunctionVersion struct {
+	Version    string
+	Runtime    string
+	EntryPoint string
+	SourceHash string
+	Created    time.Time
+}
+
+// FunctionAlias Name pointing at a version of a function, i.e. "prod"
+type FunctionAlias struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// functionAliasPayload Alias as returned by the serverless functions service, whose version may be a JSON string
+// or number
+type functionAliasPayload struct {
+	Name    string          `json:"name"`
+	Version json.RawMessage `json:"version"`
+}
+
+func (p *functionAliasPayload) toAlias() FunctionAlias {
+	return FunctionAlias{Name: p.Name, Version: decodeFunctionVersion(p.Version)}
+}
+
+// aliasNamePattern Alias names start with a letter so they never collide with version numbers
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+func validateAliasName(name string) error {
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: must start with a letter and contain only letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ListFunctionVersions Lists the published versions of a function, oldest first
+func (c *ServerlessFunctionsClient) ListFunctionVersions(functionOrid string) ([]FunctionVersion, error) {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return nil, err
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return nil, errors.New("could not acquire authentication token")
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/versions/%s", c.serviceURL, functionOrid), nil)
+	if err != nil {
+		return nil, errors.New("could not build request to fetch function versions from API")
+	}
+
+	req = withOperation(c.ctx, req, "ListFunctionVersions", functionOrid)
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request to fetch function versions: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200:
+		payload := make([]struct {
+			Version    json.RawMessage `json:"version"`
+			Runtime    string          `json:"runtime"`
+			EntryPoint string          `json:"entryPoint"`
+			SourceHash string          `json:"sourceHash"`
+			Created    string          `json:"created"`
+		}, 0)
+		err = json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return nil, errors.New("could not decode response from API of resource")
+		}
+
+		versions := make([]FunctionVersion, 0, len(payload))
+		for _, v := range payload {
+			created, err := parseFunctionTime(v.Created)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode response from API of resource: %w", err)
+			}
+			versions = append(versions, FunctionVersion{
+				Version:    decodeFunctionVersion(v.Version),
+				Runtime:    v.Runtime,
+				EntryPoint: v.EntryPoint,
+				SourceHash: v.SourceHash,
+				Created:    created,
+			})
+		}
+		return versions, nil
+	case 404:
+		return nil, fmt.Errorf("function %s: %w", functionOrid, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// ListFunctionAliases Lists the aliases of a function ordered by name
+func (c *ServerlessFunctionsClient) ListFunctionAliases(functionOrid string) ([]FunctionAlias, error) {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return nil, err
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return nil, errors.New("could not acquire authentication token")
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/aliases/%s", c.serviceURL, functionOrid), nil)
+	if err != nil {
+		return nil, errors.New("could not build request to fetch function aliases from API")
+	}
+
+	req = withOperation(c.ctx, req, "ListFunctionAliases", functionOrid)
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request to fetch function aliases: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200:
+		payload := make([]functionAliasPayload, 0)
+		err = json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return nil, errors.New("could not decode response from API of resource")
+		}
+
+		aliases := make([]FunctionAlias, 0, len(payload))
+		for _, alias := range payload {
+			aliases = append(aliases, alias.toAlias())
+		}
+		return aliases, nil
+	case 404:
+		return nil, fmt.Errorf("function %s: %w", functionOrid, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// CreateFunctionAlias Creates an alias pointing at a version of the function
+func (c *ServerlessFunctionsClient) CreateFunctionAlias(functionOrid string, name string, version string) (*FunctionAlias, error) {
+	if err := validateAliasName(name); err != nil {
+		return nil, err
+	}
+	return c.writeFunctionAlias("POST", "CreateFunctionAlias", functionOrid, "", &FunctionAlias{Name: name, Version: version})
+}
+
+// UpdateFunctionAlias Points an existing alias at another version of the function, i.e. to promote a release
+func (c *ServerlessFunctionsClient) UpdateFunctionAlias(functionOrid string, name string, version string) (*FunctionAlias, error) {
+	if err := validateAliasName(name); err != nil {
+		return nil, err
+	}
+	return c.writeFunctionAlias("PUT", "UpdateFunctionAlias", functionOrid, name, &FunctionAlias{Version: version})
+}
+
+// writeFunctionAlias Creates, or when a name is given updates, an alias
+func (c *ServerlessFunctionsClient) writeFunctionAlias(method string, operation string, functionOrid string, name string, alias *FunctionAlias) (*FunctionAlias, error) {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return nil, err
+	}
+	if alias.Version == "" {
+		return nil, errors.New("version is required")
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return nil, errors.New("could not acquire authentication token")
+	}
+
+	body, err := json.Marshal(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasURL := fmt.Sprintf("%s/v1/aliases/%s", c.serviceURL, functionOrid)
+	if name != "" {
+		aliasURL += "/" + url.PathEscape(name)
+	}
+	req, err := http.NewRequest(method, aliasURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.New("could not build request to write function alias")
+	}
+
+	req = withOperation(c.ctx, req, operation, functionOrid)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute request to write function alias: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 200, 201:
+		payload := functionAliasPayload{}
+		err = json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return nil, errors.New("could not decode response from API of resource")
+		}
+		result := payload.toAlias()
+		return &result, nil
+	case 400, 409:
+		body, _ := io.ReadAll(r.Body)
+		return nil, errors.New(string(body))
+	case 404:
+		return nil, fmt.Errorf("alias %s of function %s: %w", name, functionOrid, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
+
+// DeleteFunctionAlias Removes an alias from the function
+func (c *ServerlessFunctionsClient) DeleteFunctionAlias(functionOrid string, name string) error {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return err
+	}
+	if err := validateAliasName(name); err != nil {
+		return err
+	}
+
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
+	if err != nil {
+		return errors.New("could not acquire authentication token")
+	}
+
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v1/aliases/%s/%s", c.serviceURL, functionOrid, url.PathEscape(name)), nil)
+	if err != nil {
+		return errors.New("could not build request to delete function alias")
+	}
+
+	req = withOperation(c.ctx, req, "DeleteFunctionAlias", functionOrid)
+	req.Header.Set("Token", token)
+	r, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not execute request to delete function alias: %w", err)
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case 204:
+		return nil
+	case 404:
+		return fmt.Errorf("alias %s of function %s: %w", name, functionOrid, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(r.Body)
+		return fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
+	}
+}
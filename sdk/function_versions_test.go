@@ -0,0 +1,140 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdktest"
+)
+
+func TestFunctionVersionsAndAliases(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetInvokeHandler(func(function sdktest.Function, body []byte) ([]byte, error) {
+		return []byte(strconv.Itoa(function.Version)), nil
+	})
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+
+	source := filepath.Join(t.TempDir(), "source.zip")
+	for _, content := range []string{"v1", "v2"} {
+		os.WriteFile(source, []byte(content), 0600)
+		err := client.UpdateFunctionCode(&UpdateFunctionCodeArgs{
+			Orid:             functionOrid,
+			Runtime:          "node",
+			EntryPoint:       "index:main",
+			SourcePathOrFile: source,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error uploading code: %s", err)
+		}
+	}
+
+	versions, err := client.ListFunctionVersions(functionOrid)
+	if err != nil {
+		t.Fatalf("Unexpected error listing versions: %s", err)
+	}
+	assertInt(t, len(versions), 2, "Version count incorrect")
+	assertString(t, versions[0].Version, "1", "First version incorrect")
+	if versions[0].SourceHash == versions[1].SourceHash || versions[1].Created.IsZero() {
+		t.Errorf("Unexpected version details %+v", versions)
+	}
+
+	invokedVersion := func(qualifier string) int {
+		out := 0
+		if _, err := client.InvokeFunctionVersion(functionOrid, qualifier, nil, &out); err != nil {
+			t.Fatalf("Unexpected error invoking %q: %s", qualifier, err)
+		}
+		return out
+	}
+
+	if _, err = client.CreateFunctionAlias(functionOrid, "prod", "1"); err != nil {
+		t.Fatalf("Unexpected error creating alias: %s", err)
+	}
+	assertInt(t, invokedVersion("prod"), 1, "Alias invocation incorrect")
+	assertInt(t, invokedVersion("2"), 2, "Pinned invocation incorrect")
+	assertInt(t, invokedVersion(""), 2, "Latest invocation incorrect")
+
+	alias, err := client.UpdateFunctionAlias(functionOrid, "prod", "2")
+	if err != nil {
+		t.Fatalf("Unexpected error updating alias: %s", err)
+	}
+	assertString(t, alias.Version, "2", "Updated alias version incorrect")
+	assertInt(t, invokedVersion("prod"), 2, "Alias invocation after update incorrect")
+
+	aliases, err := client.ListFunctionAliases(functionOrid)
+	if err != nil {
+		t.Fatalf("Unexpected error listing aliases: %s", err)
+	}
+	assertInt(t, len(aliases), 1, "Alias count incorrect")
+	assertString(t, aliases[0].Name, "prod", "Alias name incorrect")
+
+	if err = client.DeleteFunctionAlias(functionOrid, "prod"); err != nil {
+		t.Fatalf("Unexpected error deleting alias: %s", err)
+	}
+	_, err = client.InvokeFunctionVersion(functionOrid, "prod", nil, nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound invoking deleted alias, got %v", err)
+	}
+	if err = client.DeleteFunctionAlias(functionOrid, "prod"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting deleted alias, got %v", err)
+	}
+}
+
+func TestCreateFunctionAliasValidatesName(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+
+	for _, name := range []string{"", "2", "prod/blue"} {
+		if _, err := client.CreateFunctionAlias(functionOrid, name, "1"); err == nil {
+			t.Errorf("Expected error for alias name %q", name)
+		}
+	}
+}
+
+func TestFunctionAliasesAcceptNumericVersions(t *testing.T) {
+	_, srv := newTestSdk(t)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1/versions/"):
+			w.Write([]byte(`[{"version":2,"runtime":"node","created":"2024-01-02T03:04:05Z"}]`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`[{"name":"prod","version":2}]`))
+		default:
+			w.Write([]byte(`{"name":"beta","version":3}`))
+		}
+	}))
+	defer api.Close()
+
+	urls := srv.URLs()
+	urls["sfUrl"] = api.URL
+	client := NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, urls).
+		GetServerlessFunctionsClient()
+	functionOrid := "orid:1:mdsCloud:::1001:sf:abc"
+
+	versions, err := client.ListFunctionVersions(functionOrid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, versions[0].Version, "2", "Version incorrect")
+	assertInt(t, versions[0].Created.Year(), 2024, "Created incorrect")
+
+	aliases, err := client.ListFunctionAliases(functionOrid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, aliases[0].Name, "prod", "Alias name incorrect")
+	assertString(t, aliases[0].Version, "2", "Alias version incorrect")
+
+	alias, err := client.CreateFunctionAlias(functionOrid, "beta", "3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, alias.Version, "3", "Alias version incorrect")
+}
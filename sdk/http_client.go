@@ -0,0 +1,216 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// TLSOptions TLS settings applied to every service client
+//
+// CABundlePath   - PEM encoded certificate authorities trusted in addition to the system pool
+// ClientCertPath - PEM encoded client certificate presented for mutual TLS
+// ClientKeyPath  - PEM encoded private key matching ClientCertPath
+// MinVersion     - Minimum TLS version accepted, i.e. tls.VersionTLS12. Zero uses the Go default.
+type TLSOptions struct {
+	CABundlePath   string
+	ClientCertPath string
+	ClientKeyPath  string
+	MinVersion     uint16
+}
+
+// httpConfig HTTP settings shared by the clients created from a single Sdk
+//
+// mu guards the settings Sdk may replace while clients are sending requests.
+type httpConfig struct {
+	mu           sync.RWMutex
+	tlsConfig    *tls.Config
+	tlsTransport *http.Transport
+	logger       Logger
+	middleware   []Middleware
+	rateLimiters map[string]*RateLimiter
+	breakers     *circuitBreakers
+}
+
+func buildTLSConfig(opts *TLSOptions) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: opts.MinVersion}
+
+	if opts.CABundlePath != "" {
+		pem, err := os.ReadFile(opts.CABundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", opts.CABundlePath)
+		}
+		config.RootCAs = pool
+	}
+
+	if opts.ClientCertPath != "" || opts.ClientKeyPath != "" {
+		if opts.ClientCertPath == "" || opts.ClientKeyPath == "" {
+			return nil, errors.New("client certificate and client key must be provided together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertPath, opts.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+func newTLSTransport(config *tls.Config) *http.Transport {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = config
+	return tr
+}
+
+// setTLSConfig Replaces the TLS configuration used by every client sharing this config
+func (hc *httpConfig) setTLSConfig(config *tls.Config) {
+	transport := newTLSTransport(config)
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.tlsConfig = config
+	hc.tlsTransport = transport
+}
+
+func (hc *httpConfig) transport(allowSelfSignCert bool) http.RoundTripper {
+	var tlsConfig *tls.Config
+	var tlsTransport *http.Transport
+	if hc != nil {
+		hc.mu.RLock()
+		tlsConfig, tlsTransport = hc.tlsConfig, hc.tlsTransport
+		hc.mu.RUnlock()
+	}
+
+	if tlsConfig != nil {
+		// NOTE: A configured CA bundle takes precedence over blindly trusting self-signed certificates.
+		if allowSelfSignCert && tlsConfig.RootCAs == nil {
+			config := tlsConfig.Clone()
+			config.InsecureSkipVerify = true
+			return newTLSTransport(config)
+		}
+		return tlsTransport
+	}
+
+	if allowSelfSignCert {
+		return newTLSTransport(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return http.DefaultTransport
+}
+
+// newClient Creates an http client for the service honoring the shared TLS, logging and middleware settings
+func (hc *httpConfig) newClient(service string, timeout time.Duration, allowSelfSignCert bool) *http.Client {
+	transport := hc.transport(allowSelfSignCert)
+	if hc == nil {
+		return &http.Client{Timeout: timeout, Transport: transport}
+	}
+
+	// NOTE: Logging sits closest to the wire so records reflect requests as altered by middleware.
+	if logger := hc.currentLogger(); logger != nil {
+		transport = &loggingTransport{next: transport, service: service, logger: logger}
+	}
+	if limiter := hc.rateLimiter(service); limiter != nil {
+		transport = &rateLimitTransport{next: transport, service: service, limiter: limiter}
+	}
+	if breakers := hc.currentBreakers(); breakers != nil {
+		transport = &circuitBreakerTransport{next: transport, service: service, breakers: breakers}
+	}
+	if middleware := hc.currentMiddleware(); len(middleware) > 0 {
+		transport = chainMiddleware(service, transport, middleware)
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// setRateLimiter Replaces the limiter for the service, removing it when nil
+func (hc *httpConfig) setRateLimiter(service string, limiter *RateLimiter) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if hc.rateLimiters == nil {
+		hc.rateLimiters = make(map[string]*RateLimiter)
+	}
+	if limiter == nil {
+		delete(hc.rateLimiters, service)
+		return
+	}
+	hc.rateLimiters[service] = limiter
+}
+
+// rateLimiter Returns the limiter for the service, falling back to the limiter shared by all services
+func (hc *httpConfig) rateLimiter(service string) *RateLimiter {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+
+	if limiter, ok := hc.rateLimiters[service]; ok {
+		return limiter
+	}
+	return hc.rateLimiters[""]
+}
+
+// setLogger Replaces the logger receiving debug records, disabling logging when nil
+func (hc *httpConfig) setLogger(logger Logger) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.logger = logger
+}
+
+// currentLogger Returns the configured logger, nil when logging is disabled
+func (hc *httpConfig) currentLogger() Logger {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.logger
+}
+
+// setBreakers Replaces the circuit breakers guarding every service, disabling them when nil
+func (hc *httpConfig) setBreakers(breakers *circuitBreakers) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.breakers = breakers
+}
+
+// currentBreakers Returns the circuit breakers, nil when disabled
+func (hc *httpConfig) currentBreakers() *circuitBreakers {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.breakers
+}
+
+// use Appends middleware applied to every client created afterwards
+func (hc *httpConfig) use(middleware ...Middleware) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.middleware = append(hc.middleware, middleware...)
+}
+
+// currentMiddleware Returns a copy of the registered middleware, safe to use while more are registered
+func (hc *httpConfig) currentMiddleware() []Middleware {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return append([]Middleware(nil), hc.middleware...)
+}
+
+// debug Writes a debug record when a logger has been configured
+func (hc *httpConfig) debug(msg string, keyvals ...interface{}) {
+	if hc == nil {
+		return
+	}
+	if logger := hc.currentLogger(); logger != nil {
+		logger.Debug(msg, keyvals...)
+	}
+}
@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func writeServerCABundle(t *testing.T, srv *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Could not write CA bundle: %s", err)
+	}
+	return path
+}
+
+func TestConfigureTLSTrustsCABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+	client := sdk.GetQueueServiceClient()
+
+	if _, err := client.httpConfig.newClient("test", API_TIMEOUT, false).Get(srv.URL); err == nil {
+		t.Fatalf("Expected unknown certificate authority to be rejected")
+	}
+
+	err := sdk.ConfigureTLS(&TLSOptions{
+		CABundlePath: writeServerCABundle(t, srv),
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error configuring TLS: %s", err)
+	}
+
+	r, err := client.httpConfig.newClient("test", API_TIMEOUT, false).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Expected CA bundle to be trusted: %s", err)
+	}
+	r.Body.Close()
+	assertInt(t, r.StatusCode, 204, "Status code incorrect")
+}
+
+func TestConfigureTLSRequiresCertAndKey(t *testing.T) {
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+
+	err := sdk.ConfigureTLS(&TLSOptions{ClientCertPath: "client.pem"})
+	if err == nil {
+		t.Errorf("Expected error when client key missing")
+	}
+}
+
+func TestConfigureTLSWhileClientsInUse(t *testing.T) {
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+	client := sdk.GetQueueServiceClient()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				client.httpConfig.newClient("test", API_TIMEOUT, j%2 == 0)
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		if err := sdk.ConfigureTLS(&TLSOptions{MinVersion: tls.VersionTLS12}); err != nil {
+			t.Fatalf("Unexpected error configuring TLS: %s", err)
+		}
+	}
+	wg.Wait()
+}
@@ -2,12 +2,14 @@ package sdk
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
 )
 
 // IdentityClient Client to interact with MDS Cloud identity service
@@ -15,6 +17,19 @@ type IdentityClient struct {
 	identityURL       string
 	allowSelfSignCert bool
 	authManager       *AuthManager
+	httpConfig        *httpConfig
+	ctx               context.Context
+}
+
+// WithContext Returns a copy of the client whose requests carry the context, i.e. for cancellation and tracing
+func (ic *IdentityClient) WithContext(ctx context.Context) IdentityAPI {
+	return ic.withContext(ctx)
+}
+
+func (ic *IdentityClient) withContext(ctx context.Context) *IdentityClient {
+	client := *ic
+	client.ctx = ctx
+	return &client
 }
 
 // RegisterAccountArgs Data needed to register a new account
@@ -33,18 +48,7 @@ type RegisterResult struct {
 }
 
 func (ic *IdentityClient) getHTTPClient() *http.Client {
-	var client *http.Client
-
-	if ic.allowSelfSignCert {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		client = &http.Client{Timeout: API_TIMEOUT, Transport: tr}
-	} else {
-		client = &http.Client{Timeout: API_TIMEOUT}
-	}
-
-	return client
+	return ic.httpConfig.newClient(orid.ServiceIdentity, API_TIMEOUT, ic.allowSelfSignCert)
 }
 
 // Register Attempts to register a new account with the MDS Cloud deployment
@@ -61,6 +65,7 @@ func (ic *IdentityClient) Register(data *RegisterAccountArgs) (*RegisterResult,
 		return nil, errors.New("could not build request to register user")
 	}
 
+	req = withOperation(ic.ctx, req, "Register", "")
 	req.Header.Set("Content-Type", "application/json")
 	r, err := client.Do(req)
 	if err != nil {
@@ -101,7 +106,7 @@ func (ic *IdentityClient) Authenticate(data *AuthenticateArgs) (*AuthenticateRes
 		"userId":    data.UserID,
 		"password":  data.Password,
 	}
-	token, err := ic.authManager.GetAuthenticationToken(overrides)
+	token, err := ic.authManager.GetAuthenticationTokenContext(ic.ctx, overrides)
 
 	if err != nil {
 		return nil, err
@@ -137,11 +142,12 @@ func (ic *IdentityClient) UpdateUser(data *UpdateUserArgs) error {
 		return errors.New("could not build request to register user")
 	}
 
-	token, err := ic.authManager.GetAuthenticationToken(nil)
+	token, err := ic.authManager.GetAuthenticationTokenContext(ic.ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	req = withOperation(ic.ctx, req, "UpdateUser", "")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -183,11 +189,12 @@ func (ic *IdentityClient) ImpersonateUser(data *ImpersonateUserArgs) (*Impersona
 		return nil, errors.New("could not build request to register user")
 	}
 
-	token, err := ic.authManager.GetAuthenticationToken(nil)
+	token, err := ic.authManager.GetAuthenticationTokenContext(ic.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(ic.ctx, req, "ImpersonateUser", "")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -224,6 +231,7 @@ func (ic *IdentityClient) GetPublicSignature() (*PublicSignatureResponse, error)
 		return nil, err
 	}
 
+	req = withOperation(ic.ctx, req, "GetPublicSignature", "")
 	req.Header.Set("Content-Type", "application/json")
 	r, err := client.Do(req)
 	if err != nil {
@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+)
+
+//go:generate mockgen -source=interfaces.go -destination=sdkmock/mocks.go -package=sdkmock
+
+// IdentityAPI Operations provided by IdentityClient
+type IdentityAPI interface {
+	WithContext(ctx context.Context) IdentityAPI
+	Register(data *RegisterAccountArgs) (*RegisterResult, error)
+	Authenticate(data *AuthenticateArgs) (*AuthenticateResult, error)
+	UpdateUser(data *UpdateUserArgs) error
+	ImpersonateUser(data *ImpersonateUserArgs) (*ImpersonateUserResult, error)
+	GetPublicSignature() (*PublicSignatureResponse, error)
+}
+
+// QueueServiceAPI Operations provided by QueueServiceClient
+type QueueServiceAPI interface {
+	WithContext(ctx context.Context) QueueServiceAPI
+	CreateQueue(data *CreateQueueArgs) (*CreateQueueResult, error)
+	DeleteQueue(data *DeleteQueueArgs) error
+	GetQueueDetails(data *GetQueueDetailsArgs) (*GetQueueDetailsResult, error)
+	UpdateQueue(data *UpdateQueueArgs) error
+}
+
+// FileServiceAPI Operations provided by FileServiceClient
+type FileServiceAPI interface {
+	WithContext(ctx context.Context) FileServiceAPI
+	CreateContainer(data *CreateContainerArgs) (*CreateContainerResult, error)
+	ListContainers() ([]ContainerSummary, error)
+	ListContainerContents(data *ListContainerContentsArgs) (*ListContainerContentsResult, error)
+	DeleteContainerOrPath(data *DeleteContainerArgs) error
+	WalkContainer(rootOrid string, fn WalkContainerFunc) error
+	UploadFile(data *UploadFileArgs) error
+	DownloadFile(fileOrid string, w io.Writer) error
+	SyncUp(localDir string, containerOrid string, opts *SyncOptions) (*SyncResult, error)
+	SyncDown(containerOrid string, localDir string, opts *SyncOptions) (*SyncResult, error)
+}
+
+// StateMachineServiceAPI Operations provided by StateMachineServiceClient
+type StateMachineServiceAPI interface {
+	WithContext(ctx context.Context) StateMachineServiceAPI
+	CreateStateMachine(data *CreateStateMachineArgs) (*CreateStateMachineResult, error)
+	GetStateMachineDetails(data *GetStateMachineDetailsArgs) (*GetStateMachineDetailsResult, error)
+	UpdateStateMachine(data *UpdateStateMachineArgs) (*UpdateStateMachineResult, error)
+	DeleteStateMachine(data *DeleteStateMachineArgs) (*DeleteStateMachineResult, error)
+}
+
+// ServerlessFunctionsAPI Operations provided by ServerlessFunctionsClient
+type ServerlessFunctionsAPI interface {
+	WithContext(ctx context.Context) ServerlessFunctionsAPI
+	CreateFunction(name string) (*ServerlessFunctionSummary, error)
+	ListFunctions() (*[]ServerlessFunctionSummary, error)
+	ListFunctionsPage(opts *ListFunctionsOptions, pageToken string) (*FunctionPage, error)
+	IterateFunctions(opts *ListFunctionsOptions) iter.Seq2[ServerlessFunctionSummary, error]
+	DeleteFunction(functionOrid string) error
+	InvokeFunction(functionOrid string, body interface{}) (interface{}, error)
+	InvokeFunctionInto(functionOrid string, input interface{}, out interface{}) (*InvocationMetadata, error)
+	InvokeFunctionVersion(functionOrid string, qualifier string, input interface{}, out interface{}) (*InvocationMetadata, error)
+	InvokeFunctionAsync(functionOrid string, input interface{}) (string, error)
+	GetInvocationResult(invocationID string) (*InvocationResult, error)
+	WaitForInvocation(invocationID string, opts *WaitOptions) (*InvocationResult, error)
+	GetFunctionDetails(functionOrid string) (*ServerlessFunctionDetails, error)
+	WaitForFunctionReady(functionOrid string, opts *WaitOptions) (*ServerlessFunctionDetails, error)
+	UpdateFunctionCode(data *UpdateFunctionCodeArgs) error
+	UpdateFunctionConfiguration(data *UpdateFunctionConfigurationArgs) error
+	GetFunctionLogs(functionOrid string, since time.Time, limit int) ([]FunctionLogEntry, error)
+	TailFunctionLogs(functionOrid string, since time.Time, opts *WaitOptions) iter.Seq2[FunctionLogEntry, error]
+	ListFunctionVersions(functionOrid string) ([]FunctionVersion, error)
+	ListFunctionAliases(functionOrid string) ([]FunctionAlias, error)
+	CreateFunctionAlias(functionOrid string, name string, version string) (*FunctionAlias, error)
+	UpdateFunctionAlias(functionOrid string, name string, version string) (*FunctionAlias, error)
+	DeleteFunctionAlias(functionOrid string, name string) error
+}
+
+var (
+	_ IdentityAPI            = (*IdentityClient)(nil)
+	_ QueueServiceAPI        = (*QueueServiceClient)(nil)
+	_ FileServiceAPI         = (*FileServiceClient)(nil)
+	_ StateMachineServiceAPI = (*StateMachineServiceClient)(nil)
+	_ ServerlessFunctionsAPI = (*ServerlessFunctionsClient)(nil)
+)
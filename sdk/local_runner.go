@@ -0,0 +1,270 @@
+package sdk
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocalRuntime Builds the command running a function's entry point from its source directory. The JSON encoded
+// invoke body is written to the command's stdin, its stdout must be the JSON result and its stderr is captured
+// as the function's logs. A non-zero exit status reports a function failure.
+type LocalRuntime func(ctx context.Context, sourceDir string, entryPoint string, functionContext string) (*exec.Cmd, error)
+
+// localRuntimes Runtimes LocalRunner can run, keyed by the runtime name given to UpdateFunctionCode
+var localRuntimes = map[string]LocalRuntime{
+	"node": nodeLocalRuntime,
+}
+
+// localRuntimesMu guards localRuntimes
+var localRuntimesMu sync.RWMutex
+
+// RegisterLocalRuntime Lets LocalRunner run functions using the named runtime, replacing any runtime already
+// registered under the name. It is safe to call while runners are being created.
+func RegisterLocalRuntime(name string, runtime LocalRuntime) {
+	localRuntimesMu.Lock()
+	defer localRuntimesMu.Unlock()
+	localRuntimes[name] = runtime
+}
+
+// localRuntime The runtime registered under the name
+func localRuntime(name string) (LocalRuntime, bool) {
+	localRuntimesMu.RLock()
+	defer localRuntimesMu.RUnlock()
+	runtime, ok := localRuntimes[name]
+	return runtime, ok
+}
+
+// nodeBootstrap Loads the entry point module, calls the exported function with the input and context, and writes
+// its result to stdout. Console output is redirected to stderr so it is captured as logs.
+const nodeBootstrap = `
+const [modulePath, exportName, functionContext] = process.argv.slice(1);
+const format = require('util').format;
+for (const level of ['log', 'info', 'debug', 'warn', 'error']) {
+  console[level] = (...args) => process.stderr.write(format(...args) + '\n');
+}
+let input = '';
+process.stdin.setEncoding('utf8');
+process.stdin.on('data', (chunk) => { input += chunk; });
+process.stdin.on('end', async () => {
+  try {
+    const mod = require(require('path').resolve(modulePath));
+    const fn = exportName ? mod[exportName] : (typeof mod === 'function' ? mod : mod.main);
+    if (typeof fn !== 'function') {
+      throw new Error('entry point ' + (exportName || 'main') + ' is not a function');
+    }
+    const result = await fn(input ? JSON.parse(input) : undefined, functionContext);
+    process.stdout.write(JSON.stringify(result === undefined ? null : result));
+  } catch (err) {
+    process.stderr.write(((err && err.stack) || String(err)) + '\n');
+    process.exitCode = 1;
+  }
+});
+`
+
+// nodeLocalRuntime Runs entry points in the form "path/to/module:exportedFunction" with node
+func nodeLocalRuntime(ctx context.Context, sourceDir string, entryPoint string, functionContext string) (*exec.Cmd, error) {
+	modulePath, exportName, _ := strings.Cut(entryPoint, ":")
+	if modulePath == "" {
+		return nil, fmt.Errorf("invalid entry point %q: expected path/to/module:function", entryPoint)
+	}
+
+	cmd := exec.CommandContext(ctx, "node", "-e", nodeBootstrap, "./"+filepath.ToSlash(modulePath), exportName, functionContext)
+	cmd.Dir = sourceDir
+	return cmd, nil
+}
+
+// LocalRunner Runs a function from local source in a subprocess, without an MDS Cloud deployment
+type LocalRunner struct {
+	sourceDir  string
+	tempDir    string
+	runtime    LocalRuntime
+	entryPoint string
+	context    string
+	version    string
+	ctx        context.Context
+}
+
+// WithContext Returns a copy of the runner whose invocations are killed when the context ends. The copy shares
+// the source of the runner, so only the original needs closing.
+func (r *LocalRunner) WithContext(ctx context.Context) *LocalRunner {
+	runner := *r
+	runner.ctx = ctx
+	return &runner
+}
+
+// NewLocalRunner Prepares to run the function described by the same arguments given to UpdateFunctionCode.
+// Directories are run in place while archives are extracted to a temporary directory removed by Close.
+func NewLocalRunner(data *UpdateFunctionCodeArgs) (*LocalRunner, error) {
+	runtime, ok := localRuntime(data.Runtime)
+	if !ok {
+		return nil, fmt.Errorf("runtime %q cannot be run locally", data.Runtime)
+	}
+	if data.EntryPoint == "" {
+		return nil, errors.New("entry point is required")
+	}
+
+	info, err := os.Stat(data.SourcePathOrFile)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &LocalRunner{runtime: runtime, entryPoint: data.EntryPoint, context: data.Context}
+	if info.IsDir() {
+		runner.sourceDir = data.SourcePathOrFile
+	} else {
+		runner.tempDir, err = os.MkdirTemp("", "mds-function-")
+		if err != nil {
+			return nil, err
+		}
+		if err = extractArchive(data.SourcePathOrFile, runner.tempDir); err != nil {
+			os.RemoveAll(runner.tempDir)
+			return nil, err
+		}
+		runner.sourceDir = runner.tempDir
+	}
+
+	runner.version, err = FunctionSourceHash(data.SourcePathOrFile, data.IgnorePatterns)
+	if err != nil {
+		runner.Close()
+		return nil, err
+	}
+	return runner, nil
+}
+
+// extractArchive Unzips the archive into dir, rejecting entries that would land outside of it
+func extractArchive(archivePath string, dir string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("could not open function archive: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		target := filepath.Join(dir, filepath.FromSlash(file.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %q is outside of the archive root", file.Name)
+		}
+
+		if file.FileInfo().IsDir() {
+			if err = os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err = extractArchiveFile(file, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractArchiveFile(file *zip.File, target string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, file.Mode().Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// Invoke Runs the function with the JSON encoded input and decodes its JSON result into out, which must be a
+// pointer or nil to discard the result. Failures of the function are returned as a *FunctionError carrying the
+// captured logs, mirroring ServerlessFunctionsClient.InvokeFunctionInto.
+func (r *LocalRunner) Invoke(input interface{}, out interface{}) (*InvocationMetadata, error) {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := r.runtime(ctx, r.sourceDir, r.entryPoint, r.context)
+	if err != nil {
+		return nil, err
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	started := time.Now()
+	err = cmd.Run()
+	metadata := &InvocationMetadata{
+		InvocationID: newLocalInvocationID(),
+		Version:      r.version,
+		Duration:     time.Since(started),
+		Logs:         stderr.String(),
+	}
+
+	exitErr := &exec.ExitError{}
+	switch {
+	case ctx.Err() != nil:
+		return metadata, ctx.Err()
+	case errors.As(err, &exitErr):
+		return metadata, &FunctionError{
+			Type:     "Unhandled",
+			Message:  lastLine(stderr.String(), fmt.Sprintf("function exited with status %d", exitErr.ExitCode())),
+			Metadata: metadata,
+		}
+	case err != nil:
+		return metadata, fmt.Errorf("could not run function locally: %w", err)
+	}
+
+	if out != nil && stdout.Len() > 0 {
+		if err = json.Unmarshal(stdout.Bytes(), out); err != nil {
+			return metadata, &InvocationDecodeError{Payload: stdout.Bytes(), Err: err}
+		}
+	}
+	return metadata, nil
+}
+
+// Close Removes the directory an archive was extracted to
+func (r *LocalRunner) Close() error {
+	if r.tempDir == "" {
+		return nil
+	}
+	return os.RemoveAll(r.tempDir)
+}
+
+// newLocalInvocationID Identifies a local invocation
+func newLocalInvocationID() string {
+	return fmt.Sprintf("local-%d", time.Now().UnixNano())
+}
+
+// lastLine The last line of the logs that is not part of a stack trace, i.e. the message of the error that ended
+// the function, or the fallback when nothing was written
+func lastLine(logs string, fallback string) string {
+	lines := strings.Split(strings.TrimSpace(logs), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line != "" && !strings.HasPrefix(line, "at ") {
+			return line
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func requireNode(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is not installed")
+	}
+}
+
+func TestLocalRunnerRunsNodeDirectory(t *testing.T) {
+	requireNode(t)
+	dir := writeSourceTree(t, map[string]string{
+		"src/one.js": `module.exports.main = async (event, context) => {
+  console.log('greeting', event.name);
+  return { message: 'hello ' + event.name, context };
+};`,
+	})
+
+	runner, err := NewLocalRunner(&UpdateFunctionCodeArgs{
+		Runtime:          "node",
+		EntryPoint:       "src/one:main",
+		SourcePathOrFile: dir,
+		Context:          "ctx",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer runner.Close()
+
+	out := struct {
+		Message string `json:"message"`
+		Context string `json:"context"`
+	}{}
+	metadata, err := runner.Invoke(greeting{Name: "Frito"}, &out)
+	if err != nil {
+		t.Fatalf("Unexpected error invoking: %s", err)
+	}
+	assertString(t, out.Message, "hello Frito", "Message incorrect")
+	assertString(t, out.Context, "ctx", "Context incorrect")
+	assertString(t, metadata.Logs, "greeting Frito\n", "Logs incorrect")
+}
+
+func TestLocalRunnerRunsNodeArchive(t *testing.T) {
+	requireNode(t)
+	dir := writeSourceTree(t, map[string]string{"index.js": `exports.handler = (event) => event.value * 2;`})
+	archive := &bytes.Buffer{}
+	if err := writeSourceArchive(archive, dir, nil); err != nil {
+		t.Fatalf("Unexpected error archiving: %s", err)
+	}
+	source := filepath.Join(t.TempDir(), "source.zip")
+	os.WriteFile(source, archive.Bytes(), 0600)
+
+	runner, err := NewLocalRunner(&UpdateFunctionCodeArgs{Runtime: "node", EntryPoint: "index:handler", SourcePathOrFile: source})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	out := 0
+	if _, err = runner.Invoke(map[string]int{"value": 21}, &out); err != nil {
+		t.Fatalf("Unexpected error invoking: %s", err)
+	}
+	assertInt(t, out, 42, "Result incorrect")
+
+	runner.Close()
+	if _, err = os.Stat(runner.tempDir); !os.IsNotExist(err) {
+		t.Errorf("Expected extracted source to be removed, got %v", err)
+	}
+}
+
+func TestLocalRunnerReportsFunctionErrors(t *testing.T) {
+	requireNode(t)
+	dir := writeSourceTree(t, map[string]string{"index.js": `exports.main = () => { console.log('about to fail'); throw new Error('boom'); };`})
+
+	runner, err := NewLocalRunner(&UpdateFunctionCodeArgs{Runtime: "node", EntryPoint: "index:main", SourcePathOrFile: dir})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer runner.Close()
+
+	_, err = runner.Invoke(nil, nil)
+	functionErr := &FunctionError{}
+	if !errors.As(err, &functionErr) {
+		t.Fatalf("Expected function error, got %v", err)
+	}
+	assertString(t, functionErr.Message, "Error: boom", "Message incorrect")
+	if !strings.HasPrefix(functionErr.Metadata.Logs, "about to fail\n") {
+		t.Errorf("Logs incorrect: %q", functionErr.Metadata.Logs)
+	}
+}
+
+func TestLocalRunnerCustomRuntime(t *testing.T) {
+	RegisterLocalRuntime("cat", func(ctx context.Context, sourceDir string, entryPoint string, functionContext string) (*exec.Cmd, error) {
+		return exec.CommandContext(ctx, "cat"), nil
+	})
+
+	runner, err := NewLocalRunner(&UpdateFunctionCodeArgs{Runtime: "cat", EntryPoint: "any", SourcePathOrFile: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer runner.Close()
+
+	out := greeting{}
+	if _, err = runner.Invoke(greeting{Name: "Frito"}, &out); err != nil {
+		t.Fatalf("Unexpected error invoking: %s", err)
+	}
+	assertString(t, out.Name, "Frito", "Echoed input incorrect")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = runner.WithContext(ctx).Invoke(nil, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation error, got %v", err)
+	}
+
+	if _, err = NewLocalRunner(&UpdateFunctionCodeArgs{Runtime: "cobol", EntryPoint: "any", SourcePathOrFile: t.TempDir()}); err == nil {
+		t.Error("Expected error for unsupported runtime")
+	}
+}
+
+func TestExtractArchiveRejectsEscapingEntries(t *testing.T) {
+	archive := &bytes.Buffer{}
+	writer := zipWriterWith(t, archive, "../evil.txt")
+	writer.Close()
+	source := filepath.Join(t.TempDir(), "evil.zip")
+	os.WriteFile(source, archive.Bytes(), 0600)
+
+	if err := extractArchive(source, t.TempDir()); err == nil {
+		t.Error("Expected error for entry outside of the archive root")
+	}
+}
+
+func zipWriterWith(t *testing.T, w *bytes.Buffer, name string) *zip.Writer {
+	writer := zip.NewWriter(w)
+	entry, err := writer.Create(name)
+	if err != nil {
+		t.Fatalf("Unexpected error creating entry: %s", err)
+	}
+	entry.Write([]byte("content"))
+	return writer
+}
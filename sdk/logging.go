@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Logger Receives debug records describing the requests made by the SDK
+//
+// The signature matches (*slog.Logger).Debug so a *slog.Logger may be supplied directly. Key value pairs
+// alternate between a string key and its value.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+}
+
+// redactedValue Replacement written in place of credentials
+const redactedValue = "REDACTED"
+
+var sensitiveQueryKeys = []string{"token", "password", "secret"}
+
+// withAttempt Records the attempt number of a request so it may be reported alongside the request
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptContextKey, attempt)
+}
+
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptContextKey).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// redactURL Formats the URL with any credentials replaced
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		if _, ok := redacted.User.Password(); ok {
+			redacted.User = url.UserPassword(redacted.User.Username(), redactedValue)
+		}
+	}
+
+	query := redacted.Query()
+	changed := false
+	for key := range query {
+		for _, sensitive := range sensitiveQueryKeys {
+			if strings.Contains(strings.ToLower(key), sensitive) {
+				query.Set(key, redactedValue)
+				changed = true
+			}
+		}
+	}
+	if changed {
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.String()
+}
+
+// loggingTransport Records every request at debug level. Request headers and bodies are never logged as
+// they carry authentication tokens and passwords.
+type loggingTransport struct {
+	next    http.RoundTripper
+	service string
+	logger  Logger
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	r, err := t.next.RoundTrip(req)
+
+	keyvals := []interface{}{
+		"service", t.service,
+		"operation", RequestOperation(req),
+		"method", req.Method,
+		"url", redactURL(req.URL),
+		"attempt", attemptFromContext(req.Context()),
+		"latency", time.Since(start),
+	}
+	if err != nil {
+		t.logger.Debug("mds request failed", append(keyvals, "error", err)...)
+		return r, err
+	}
+
+	t.logger.Debug("mds request", append(keyvals, "status", r.StatusCode)...)
+	return r, err
+}
@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// RoundTrip Sends a single HTTP request to an MDS Cloud service and returns its response
+type RoundTrip func(req *http.Request) (*http.Response, error)
+
+// Middleware Wraps a RoundTrip to inspect or alter requests and responses
+//
+// Middleware may add headers, record metrics or short-circuit a request. Requests must be cloned, i.e. with
+// req.Clone, before they are modified.
+type Middleware func(next RoundTrip) RoundTrip
+
+type contextKey int
+
+const (
+	attemptContextKey contextKey = iota
+	serviceContextKey
+	operationContextKey
+	oridContextKey
+)
+
+// RequestService Returns the orid service identifier, i.e. "qs", of the client that issued the request
+func RequestService(req *http.Request) string {
+	if service, ok := req.Context().Value(serviceContextKey).(string); ok {
+		return service
+	}
+	return ""
+}
+
+// middlewareTransport Adapts a middleware chain to an http.RoundTripper
+type middlewareTransport struct {
+	service   string
+	roundTrip RoundTrip
+}
+
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.WithContext(context.WithValue(req.Context(), serviceContextKey, t.service))
+	return t.roundTrip(req)
+}
+
+// chainMiddleware Wraps the transport so the first middleware registered is the first to see each request
+func chainMiddleware(service string, transport http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	roundTrip := RoundTrip(transport.RoundTrip)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		roundTrip = middleware[i](roundTrip)
+	}
+
+	return &middlewareTransport{service: service, roundTrip: roundTrip}
+}
+
+// RequestOperation Returns the name of the SDK operation, i.e. "CreateQueue", that issued the request
+func RequestOperation(req *http.Request) string {
+	if operation, ok := req.Context().Value(operationContextKey).(string); ok {
+		return operation
+	}
+	return ""
+}
+
+// RequestOrid Returns the orid the request acts against, or an empty string when it has none
+func RequestOrid(req *http.Request) string {
+	if resourceOrid, ok := req.Context().Value(oridContextKey).(string); ok {
+		return resourceOrid
+	}
+	return ""
+}
+
+// withOperation Tags the request with the SDK operation and orid it was issued for. A nil ctx keeps the
+// request's existing context.
+func withOperation(ctx context.Context, req *http.Request, operation string, resourceOrid string) *http.Request {
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	ctx = context.WithValue(ctx, operationContextKey, operation)
+	ctx = context.WithValue(ctx, oridContextKey, resourceOrid)
+	return req.WithContext(ctx)
+}
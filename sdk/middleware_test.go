@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestMiddlewareAppliesToEveryRequest(t *testing.T) {
+	seenHeaders := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenHeaders = append(seenHeaders, r.Header.Get("X-Request-Id"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/authenticate" {
+			w.Write([]byte(`{"token":"test-token"}`))
+			return
+		}
+		w.Write([]byte(`{"resource":"test"}`))
+	}))
+	defer srv.Close()
+
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{
+		"identityUrl": srv.URL,
+		"qsUrl":       srv.URL,
+	})
+
+	services := make([]string, 0)
+	sdk.Use(func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			services = append(services, RequestService(req))
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Request-Id", "request-1")
+			return next(req)
+		}
+	})
+
+	_, err := sdk.GetQueueServiceClient().GetQueueDetails(&GetQueueDetailsArgs{
+		Orid: "orid:1:mdsCloud:::1001:qs:testQueue",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	assertInt(t, len(seenHeaders), 2, "Request count incorrect")
+	for _, header := range seenHeaders {
+		assertString(t, header, "request-1", "Middleware header missing")
+	}
+	assertString(t, services[0], "identity", "First request service incorrect")
+	assertString(t, services[1], "qs", "Second request service incorrect")
+}
+
+func TestUseWhileClientsInUse(t *testing.T) {
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+	passThrough := func(next RoundTrip) RoundTrip { return next }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				sdk.httpConfig.newClient("sf", API_TIMEOUT, false)
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		sdk.Use(passThrough)
+	}
+	wg.Wait()
+}
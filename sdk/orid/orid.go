@@ -0,0 +1,225 @@
+// Package orid Parses, validates and builds MDS Cloud object resource ids (orids)
+//
+// A version 1 orid has the form
+//
+//	orid:1:<provider>:<custom3>:<custom4>:<account>:<service>:<resourceId>[/<resourceRider>]
+//
+// where the optional resource rider identifies a sub-resource such as a path within a
+// file service container. Riders may also be separated from the resource id with a colon.
+package orid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Prefix Leading segment of every orid
+const Prefix = "orid"
+
+// Version Orid specification version supported by this package
+const Version = "1"
+
+// Service identifiers used within the service segment of an orid
+const (
+	ServiceIdentity            = "identity"
+	ServiceQueue               = "qs"
+	ServiceFile                = "fs"
+	ServiceStateMachine        = "sm"
+	ServiceServerlessFunctions = "sf"
+	ServiceNotification        = "ns"
+)
+
+var serviceNames = map[string]string{
+	ServiceIdentity:            "identity service",
+	ServiceQueue:               "queue service",
+	ServiceFile:                "file service",
+	ServiceStateMachine:        "state machine service",
+	ServiceServerlessFunctions: "serverless functions service",
+	ServiceNotification:        "notification service",
+}
+
+var resourceTypes = map[string]string{
+	ServiceIdentity:            "account",
+	ServiceQueue:               "queue",
+	ServiceFile:                "container",
+	ServiceStateMachine:        "state machine",
+	ServiceServerlessFunctions: "function",
+	ServiceNotification:        "topic",
+}
+
+// ErrInvalid Returned, wrapped, when a string is not a well formed orid
+var ErrInvalid = errors.New("invalid orid")
+
+const segmentCount = 8
+
+// Orid A parsed MDS Cloud object resource id
+type Orid struct {
+	Provider       string
+	Custom3        string
+	Custom4        string
+	AccountID      string
+	Service        string
+	ResourceID     string
+	ResourceRider  string
+	RiderSeparator string
+}
+
+// New Creates a new orid for the MDS Cloud provider
+func New(accountID string, service string, resourceID string) *Orid {
+	return &Orid{
+		Provider:   "mdsCloud",
+		AccountID:  accountID,
+		Service:    service,
+		ResourceID: resourceID,
+	}
+}
+
+// Parse Parses a string into an orid, returning an error wrapping ErrInvalid when malformed
+func Parse(value string) (*Orid, error) {
+	parts := strings.SplitN(value, ":", segmentCount)
+	if len(parts) < segmentCount {
+		return nil, fmt.Errorf("%w %q: expected %d colon separated segments", ErrInvalid, value, segmentCount)
+	}
+	if parts[0] != Prefix {
+		return nil, fmt.Errorf("%w %q: must start with %q", ErrInvalid, value, Prefix+":")
+	}
+	if parts[1] != Version {
+		return nil, fmt.Errorf("%w %q: unsupported version %q", ErrInvalid, value, parts[1])
+	}
+
+	o := &Orid{
+		Provider:  parts[2],
+		Custom3:   parts[3],
+		Custom4:   parts[4],
+		AccountID: parts[5],
+		Service:   parts[6],
+	}
+
+	resource := parts[7]
+	if i := strings.IndexAny(resource, ":/"); i >= 0 {
+		o.ResourceID = resource[:i]
+		o.RiderSeparator = resource[i : i+1]
+		o.ResourceRider = resource[i+1:]
+	} else {
+		o.ResourceID = resource
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalid, value, err)
+	}
+	return o, nil
+}
+
+// IsValid Reports whether the string is a well formed orid
+func IsValid(value string) bool {
+	_, err := Parse(value)
+	return err == nil
+}
+
+// Validate Checks that the required segments of the orid are present
+func (o *Orid) Validate() error {
+	switch {
+	case o.Provider == "":
+		return errors.New("provider is required")
+	case o.Service == "":
+		return errors.New("service is required")
+	case o.ResourceID == "":
+		return errors.New("resource id is required")
+	case strings.ContainsAny(o.Provider+o.Custom3+o.Custom4+o.AccountID+o.Service, ":/"):
+		return errors.New("segments may not contain ':' or '/'")
+	case strings.ContainsAny(o.ResourceID, ":/"):
+		return errors.New("resource id may not contain ':' or '/'")
+	case o.RiderSeparator != "" && o.RiderSeparator != ":" && o.RiderSeparator != "/":
+		return fmt.Errorf("unsupported resource rider separator %q", o.RiderSeparator)
+	}
+	return nil
+}
+
+// String Formats the orid in its canonical string form
+func (o *Orid) String() string {
+	value := strings.Join([]string{
+		Prefix,
+		Version,
+		o.Provider,
+		o.Custom3,
+		o.Custom4,
+		o.AccountID,
+		o.Service,
+		o.ResourceID,
+	}, ":")
+
+	if o.ResourceRider != "" {
+		separator := o.RiderSeparator
+		if separator == "" {
+			separator = "/"
+		}
+		value += separator + o.ResourceRider
+	}
+	return value
+}
+
+// ResourceType Human readable type of resource the orid identifies, i.e. "queue" or "container"
+func (o *Orid) ResourceType() string {
+	if resourceType, ok := resourceTypes[o.Service]; ok {
+		return resourceType
+	}
+	return o.Service
+}
+
+// ServiceName Human readable name of the service the orid belongs to
+func (o *Orid) ServiceName() string {
+	return ServiceName(o.Service)
+}
+
+// SubPath Resource rider of the orid split into its path elements
+func (o *Orid) SubPath() []string {
+	if o.ResourceRider == "" {
+		return nil
+	}
+	return strings.Split(strings.Trim(o.ResourceRider, "/"), "/")
+}
+
+// Join Creates a copy of the orid with the path elements appended to the resource rider
+func (o *Orid) Join(elem ...string) *Orid {
+	joined := *o
+	parts := make([]string, 0, len(elem)+1)
+	if o.ResourceRider != "" {
+		parts = append(parts, strings.Trim(o.ResourceRider, "/"))
+	}
+	for _, e := range elem {
+		if e = strings.Trim(e, "/"); e != "" {
+			parts = append(parts, e)
+		}
+	}
+
+	joined.ResourceRider = strings.Join(parts, "/")
+	if joined.ResourceRider != "" {
+		joined.RiderSeparator = "/"
+	}
+	return &joined
+}
+
+// Root Creates a copy of the orid without a resource rider
+func (o *Orid) Root() *Orid {
+	root := *o
+	root.ResourceRider = ""
+	root.RiderSeparator = ""
+	return &root
+}
+
+// ExpectService Returns an error describing the mismatch when the orid does not belong to the service
+func (o *Orid) ExpectService(service string) error {
+	if o.Service != service {
+		return fmt.Errorf("orid %q belongs to the %s (%s), not the %s (%s)", o.String(), o.ServiceName(), o.Service, ServiceName(service), service)
+	}
+	return nil
+}
+
+// ServiceName Human readable name for a service identifier
+func ServiceName(service string) string {
+	if name, ok := serviceNames[service]; ok {
+		return name
+	}
+	return fmt.Sprintf("%q service", service)
+}
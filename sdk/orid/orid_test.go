@@ -0,0 +1,107 @@
+package orid
+
+import (
+	"errors"
+	"testing"
+)
+
+func assertString(t *testing.T, value string, expected string, message string) {
+	if value != expected {
+		t.Errorf("%s, got: %s, expected: %s", message, value, expected)
+	}
+}
+
+func TestParse(t *testing.T) {
+	o, err := Parse("orid:1:mdsCloud:::1001:qs:testQueue")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	assertString(t, o.Provider, "mdsCloud", "Provider incorrect")
+	assertString(t, o.AccountID, "1001", "Account incorrect")
+	assertString(t, o.Service, ServiceQueue, "Service incorrect")
+	assertString(t, o.ResourceID, "testQueue", "Resource id incorrect")
+	assertString(t, o.ResourceRider, "", "Resource rider incorrect")
+	assertString(t, o.ResourceType(), "queue", "Resource type incorrect")
+	assertString(t, o.ServiceName(), "queue service", "Service name incorrect")
+}
+
+func TestParseResourceRider(t *testing.T) {
+	o, err := Parse("orid:1:mdsCloud:::1001:fs:container/sub/dir")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	assertString(t, o.ResourceID, "container", "Resource id incorrect")
+	assertString(t, o.ResourceRider, "sub/dir", "Resource rider incorrect")
+	assertString(t, o.RiderSeparator, "/", "Rider separator incorrect")
+	if len(o.SubPath()) != 2 {
+		t.Errorf("Sub path incorrect, got: %v", o.SubPath())
+	}
+
+	o, err = Parse("orid:1:mdsCloud:::1001:sm:machine:execution")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, o.ResourceRider, "execution", "Resource rider incorrect")
+	assertString(t, o.RiderSeparator, ":", "Rider separator incorrect")
+}
+
+func TestParseInvalid(t *testing.T) {
+	values := []string{
+		"",
+		"queue-name",
+		"orid:1:mdsCloud:::1001:qs",
+		"orid:2:mdsCloud:::1001:qs:testQueue",
+		"urn:1:mdsCloud:::1001:qs:testQueue",
+		"orid:1::::1001:qs:testQueue",
+		"orid:1:mdsCloud:::1001::testQueue",
+		"orid:1:mdsCloud:::1001:qs:",
+		"orid:1:mdsCloud:::1001:fs:/sub/dir",
+	}
+
+	for _, value := range values {
+		_, err := Parse(value)
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected %q to be invalid, got: %v", value, err)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	values := []string{
+		"orid:1:mdsCloud:::1001:qs:testQueue",
+		"orid:1:mdsCloud:::1001:fs:container/sub/dir",
+		"orid:1:mdsCloud:::1001:sm:machine:execution",
+	}
+
+	for _, value := range values {
+		o, err := Parse(value)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		assertString(t, o.String(), value, "Round trip incorrect")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	o := New("1001", ServiceFile, "container")
+
+	assertString(t, o.Join("sub", "/dir/").String(), "orid:1:mdsCloud:::1001:fs:container/sub/dir", "Join incorrect")
+	assertString(t, o.Join("sub").Join("file.txt").String(), "orid:1:mdsCloud:::1001:fs:container/sub/file.txt", "Nested join incorrect")
+	assertString(t, o.Join("sub").Root().String(), "orid:1:mdsCloud:::1001:fs:container", "Root incorrect")
+}
+
+func TestExpectService(t *testing.T) {
+	o := New("1001", ServiceStateMachine, "machine")
+
+	if err := o.ExpectService(ServiceStateMachine); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	err := o.ExpectService(ServiceQueue)
+	if err == nil {
+		t.Fatalf("Expected service mismatch error")
+	}
+	assertString(t, err.Error(), `orid "orid:1:mdsCloud:::1001:sm:machine" belongs to the state machine service (sm), not the queue service (qs)`, "Error message incorrect")
+}
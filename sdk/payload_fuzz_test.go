@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+)
+
+func assertSingleLevelJSONObject(t *testing.T, body []byte, expected map[string]string) {
+	if !json.Valid(body) {
+		t.Fatalf("Payload is not valid JSON: %s", body)
+	}
+
+	decoded := make(map[string]interface{})
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Payload could not be decoded: %s", err)
+	}
+
+	assertInt(t, len(decoded), len(expected), "Payload key count incorrect")
+	for key, value := range expected {
+		raw, ok := decoded[key]
+		if !ok {
+			t.Fatalf("Payload missing key %s: %s", key, body)
+		}
+		str, ok := raw.(string)
+		if !ok {
+			t.Fatalf("Payload key %s is not a string: %s", key, body)
+		}
+
+		// NOTE: encoding/json replaces invalid UTF-8 with U+FFFD so only valid strings round trip exactly.
+		if utf8.ValidString(value) {
+			assertString(t, str, value, "Payload value incorrect for "+key)
+		}
+	}
+}
+
+func FuzzBuildAuthenticatePayload(f *testing.F) {
+	f.Add("1001", "user", "password")
+	f.Add("", "", "")
+	f.Add(`1001","admin":"true`, `user\`, `pa"ss\"word`)
+	f.Add("\x00\x1f", "</script>", "  ")
+	f.Add("\xff\xfe", "user\n", "p\tw")
+
+	f.Fuzz(func(t *testing.T, account string, user string, password string) {
+		body, err := buildAuthenticatePayload(account, user, password)
+		if err != nil {
+			t.Fatalf("Unexpected error building payload: %s", err)
+		}
+
+		assertSingleLevelJSONObject(t, body, map[string]string{
+			"accountId": account,
+			"userId":    user,
+			"password":  password,
+		})
+	})
+}
+
+func FuzzBuildCreateFunctionPayload(f *testing.F) {
+	f.Add("test")
+	f.Add("")
+	f.Add(`test","runtime":"node`)
+	f.Add(`back\slash`)
+	f.Add("\xff")
+
+	f.Fuzz(func(t *testing.T, name string) {
+		body, err := buildCreateFunctionPayload(name)
+		if err != nil {
+			t.Fatalf("Unexpected error building payload: %s", err)
+		}
+
+		assertSingleLevelJSONObject(t, body, map[string]string{
+			"name": name,
+		})
+	})
+}
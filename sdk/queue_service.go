@@ -2,17 +2,33 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
 )
 
 // QueueServiceClient Client to interact with the MDS Cloud queue service
 type QueueServiceClient struct {
 	queueServiceURL string
 	authManager     *AuthManager
+	httpConfig      *httpConfig
+	ctx             context.Context
+}
+
+// WithContext Returns a copy of the client whose requests carry the context, i.e. for cancellation and tracing
+func (qs *QueueServiceClient) WithContext(ctx context.Context) QueueServiceAPI {
+	return qs.withContext(ctx)
+}
+
+func (qs *QueueServiceClient) withContext(ctx context.Context) *QueueServiceClient {
+	client := *qs
+	client.ctx = ctx
+	return &client
 }
 
 // CreateQueueArgs Data needed to create a new queue
@@ -31,7 +47,13 @@ type CreateQueueResult struct {
 
 // CreateQueue Attempts to create a new queue with the MDS Cloud deployment
 func (qs *QueueServiceClient) CreateQueue(data *CreateQueueArgs) (*CreateQueueResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if data.Dlq != "" {
+		if _, err := validateOrid(data.Dlq, orid.ServiceQueue); err != nil {
+			return nil, err
+		}
+	}
+
+	client := qs.httpConfig.newClient(orid.ServiceQueue, API_TIMEOUT, false)
 
 	body, err := json.Marshal(data)
 	if err != nil {
@@ -43,11 +65,12 @@ func (qs *QueueServiceClient) CreateQueue(data *CreateQueueArgs) (*CreateQueueRe
 		return nil, errors.New("could not build request to create queue")
 	}
 
-	token, err := qs.authManager.GetAuthenticationToken(nil)
+	token, err := qs.authManager.GetAuthenticationTokenContext(qs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(qs.ctx, req, "CreateQueue", "")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -89,18 +112,23 @@ type DeleteQueueArgs struct {
 
 // DeleteQueue Attempts to delete a queue from the MDS Cloud deployment
 func (qs *QueueServiceClient) DeleteQueue(data *DeleteQueueArgs) error {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if _, err := validateOrid(data.Orid, orid.ServiceQueue); err != nil {
+		return err
+	}
+
+	client := qs.httpConfig.newClient(orid.ServiceQueue, API_TIMEOUT, false)
 
 	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v1/queue/%s", qs.queueServiceURL, data.Orid), nil)
 	if err != nil {
 		return errors.New("could not build request to delete queue")
 	}
 
-	token, err := qs.authManager.GetAuthenticationToken(nil)
+	token, err := qs.authManager.GetAuthenticationTokenContext(qs.ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	req = withOperation(qs.ctx, req, "DeleteQueue", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -136,18 +164,23 @@ type GetQueueDetailsResult struct {
 
 // GetQueueDetails Gets details for the specified queue
 func (qs *QueueServiceClient) GetQueueDetails(data *GetQueueDetailsArgs) (*GetQueueDetailsResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if _, err := validateOrid(data.Orid, orid.ServiceQueue); err != nil {
+		return nil, err
+	}
+
+	client := qs.httpConfig.newClient(orid.ServiceQueue, API_TIMEOUT, false)
 
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/queue/%s/details", qs.queueServiceURL, data.Orid), nil)
 	if err != nil {
 		return nil, errors.New("could not build request to delete queue")
 	}
 
-	token, err := qs.authManager.GetAuthenticationToken(nil)
+	token, err := qs.authManager.GetAuthenticationTokenContext(qs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(qs.ctx, req, "GetQueueDetails", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -188,7 +221,16 @@ type UpdateQueueArgs struct {
 
 // UpdateQueue Attempts to create a new queue with the MDS Cloud deployment
 func (qs *QueueServiceClient) UpdateQueue(data *UpdateQueueArgs) error {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if _, err := validateOrid(data.Orid, orid.ServiceQueue); err != nil {
+		return err
+	}
+	if data.Dlq != "" && data.Dlq != "NULL" {
+		if _, err := validateOrid(data.Dlq, orid.ServiceQueue); err != nil {
+			return err
+		}
+	}
+
+	client := qs.httpConfig.newClient(orid.ServiceQueue, API_TIMEOUT, false)
 
 	type updateQueuePayload struct {
 		Resource interface{} `json:"resource,omitempty"`
@@ -218,11 +260,12 @@ func (qs *QueueServiceClient) UpdateQueue(data *UpdateQueueArgs) error {
 		return errors.New("could not build request to create queue")
 	}
 
-	token, err := qs.authManager.GetAuthenticationToken(nil)
+	token, err := qs.authManager.GetAuthenticationTokenContext(qs.ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	req = withOperation(qs.ctx, req, "UpdateQueue", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
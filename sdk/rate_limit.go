@@ -0,0 +1,205 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitMode Behavior of a rate limiter when no request capacity is available
+type RateLimitMode int
+
+const (
+	// RateLimitBlock Waits until capacity is available or the request context ends
+	RateLimitBlock RateLimitMode = iota
+	// RateLimitFailFast Returns a *RateLimitError immediately
+	RateLimitFailFast
+)
+
+// ErrRateLimited Matches, through errors.Is, every *RateLimitError
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitError Returned when a request was not sent, or was rejected with a 429, because of rate limiting
+type RateLimitError struct {
+	Service    string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s requests, retry after %s", e.Service, e.RetryAfter)
+}
+
+// Is Reports whether the target is ErrRateLimited
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RateLimitOptions Settings for a token bucket rate limiter
+//
+// RequestsPerSecond - Rate the bucket refills at. Zero only honors Retry-After responses.
+// Burst             - Maximum requests sent back to back once the bucket is full. Defaults to 1.
+// Mode              - Whether requests wait for capacity or fail immediately
+// MaxRetries        - Times a request rejected with a 429 is retried after waiting, when blocking
+type RateLimitOptions struct {
+	RequestsPerSecond float64
+	Burst             int
+	Mode              RateLimitMode
+	MaxRetries        int
+}
+
+// RateLimiter Token bucket limiting the requests sent to one or more services
+type RateLimiter struct {
+	mu           sync.Mutex
+	opts         RateLimitOptions
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter Creates a new rate limiter with a full bucket
+func NewRateLimiter(opts *RateLimitOptions) *RateLimiter {
+	limiter := RateLimiter{opts: *opts}
+	if limiter.opts.Burst < 1 {
+		limiter.opts.Burst = 1
+	}
+	limiter.tokens = float64(limiter.opts.Burst)
+	limiter.last = time.Now()
+
+	return &limiter
+}
+
+// reserve Takes a token, returning how long the caller must wait before using it. When failing fast and
+// capacity is unavailable no token is taken and false is returned.
+func (l *RateLimiter) reserve() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var wait time.Duration
+	if now.Before(l.blockedUntil) {
+		wait = l.blockedUntil.Sub(now)
+	}
+
+	if l.opts.RequestsPerSecond <= 0 {
+		return wait, wait == 0 || l.opts.Mode == RateLimitBlock
+	}
+
+	l.tokens += now.Sub(l.last).Seconds() * l.opts.RequestsPerSecond
+	if l.tokens > float64(l.opts.Burst) {
+		l.tokens = float64(l.opts.Burst)
+	}
+	l.last = now
+
+	tokens := l.tokens - 1
+	if tokens < 0 {
+		tokenWait := time.Duration(-tokens / l.opts.RequestsPerSecond * float64(time.Second))
+		if tokenWait > wait {
+			wait = tokenWait
+		}
+	}
+
+	if wait > 0 && l.opts.Mode == RateLimitFailFast {
+		return wait, false
+	}
+	l.tokens = tokens
+	return wait, true
+}
+
+// pause Holds back every request through the limiter for the duration, i.e. after a Retry-After response
+func (l *RateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// wait Blocks until the request may be sent, the context ends or, when failing fast, capacity is unavailable
+func (l *RateLimiter) wait(ctx context.Context, service string) error {
+	delay, ok := l.reserve()
+	if !ok {
+		return &RateLimitError{Service: service, RetryAfter: delay}
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter Reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// rateLimitTransport Sends requests within the limits of the rate limiter, retrying 429 responses
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	service string
+	limiter *RateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if err := t.limiter.wait(ctx, t.service); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req.WithContext(withAttempt(ctx, attempt))
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		r, err := t.next.RoundTrip(attemptReq)
+		if err != nil || r.StatusCode != http.StatusTooManyRequests {
+			return r, err
+		}
+
+		retryAfter := parseRetryAfter(r.Header.Get("Retry-After"), time.Now())
+		t.limiter.pause(retryAfter)
+
+		if t.limiter.opts.Mode == RateLimitFailFast {
+			io.Copy(io.Discard, r.Body)
+			r.Body.Close()
+			return nil, &RateLimitError{Service: t.service, RetryAfter: retryAfter}
+		}
+
+		canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if attempt > t.limiter.opts.MaxRetries || !canReplay {
+			return r, nil
+		}
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+	}
+}
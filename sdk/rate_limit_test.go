@@ -0,0 +1,113 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterFailFast(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+	sdk.SetRateLimiter("", NewRateLimiter(&RateLimitOptions{
+		RequestsPerSecond: 0.001,
+		Burst:             2,
+		Mode:              RateLimitFailFast,
+	}))
+	client := sdk.httpConfig.newClient("sf", API_TIMEOUT, false)
+
+	for i := 0; i < 2; i++ {
+		r, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Unexpected error within burst: %s", err)
+		}
+		r.Body.Close()
+	}
+
+	_, err := client.Get(srv.URL)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected rate limit error, got: %v", err)
+	}
+}
+
+func TestRateLimiterRetriesTooManyRequests(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	logger := &recordingLogger{}
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+	sdk.SetLogger(logger)
+	sdk.SetRateLimiter("qs", NewRateLimiter(&RateLimitOptions{MaxRetries: 1}))
+
+	r, err := sdk.httpConfig.newClient("qs", API_TIMEOUT, false).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	r.Body.Close()
+
+	assertInt(t, r.StatusCode, 204, "Status code incorrect")
+	assertInt(t, requests, 2, "Request count incorrect")
+	assertInt(t, len(logger.records), 2, "Record count incorrect")
+}
+
+func TestRateLimiterHonorsRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(429)
+	}))
+	defer srv.Close()
+
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+	sdk.SetRateLimiter("", NewRateLimiter(&RateLimitOptions{Mode: RateLimitFailFast}))
+	client := sdk.httpConfig.newClient("fs", API_TIMEOUT, false)
+
+	_, err := client.Get(srv.URL)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected rate limit error, got: %v", err)
+	}
+
+	_, err = client.Get(srv.URL)
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("Expected rate limit error before sending, got: %v", err)
+	}
+	if rateLimitErr.RetryAfter <= 25*time.Second {
+		t.Errorf("Expected remaining Retry-After delay, got: %s", rateLimitErr.RetryAfter)
+	}
+}
+
+func TestSetRateLimiterWhileClientsInUse(t *testing.T) {
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				sdk.httpConfig.newClient("sf", API_TIMEOUT, false)
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		sdk.SetRateLimiter("sf", NewRateLimiter(&RateLimitOptions{RequestsPerSecond: 10, Burst: 1}))
+		sdk.SetRateLimiter("sf", nil)
+	}
+	wg.Wait()
+}
@@ -12,6 +12,7 @@ type Sdk struct {
 	defaultAuthManager  *AuthManager
 	allowSelfCert       bool
 	enableAuthSemaphore bool
+	httpConfig          *httpConfig
 }
 
 // NewSdk Creates a new SDK object
@@ -46,6 +47,8 @@ func NewSdk(account string, userID string, password string, allowSelfCert bool,
 		nsURL:       urls["nsUrl"],
 		sfURL:       urls["sfUrl"],
 	}
+	sdk.httpConfig = &httpConfig{}
+	authManager.httpConfig = sdk.httpConfig
 	sdk.defaultAccount = account
 	sdk.defaultAuthManager = authManager
 	sdk.allowSelfCert = allowSelfCert
@@ -53,11 +56,70 @@ func NewSdk(account string, userID string, password string, allowSelfCert bool,
 	return &sdk
 }
 
+// ConfigureTLS Applies a CA bundle, client certificate and minimum TLS version to every service client
+//
+// The settings replace the InsecureSkipVerify behavior of allowSelfCert whenever a CA bundle is supplied and
+// apply to clients previously handed out by this SDK object as well as future ones.
+func (s *Sdk) ConfigureTLS(opts *TLSOptions) error {
+	config, err := buildTLSConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	s.httpConfig.setTLSConfig(config)
+	return nil
+}
+
+// SetLogger Sets the logger receiving debug records for every request made by this SDK object's clients
+//
+// Passing nil disables logging. A *slog.Logger may be supplied directly.
+func (s *Sdk) SetLogger(logger Logger) {
+	s.httpConfig.setLogger(logger)
+}
+
+// Use Appends middleware applied to every request made by this SDK object's clients
+//
+// Middleware run in the order registered, including for the authentication requests made by the auth manager.
+func (s *Sdk) Use(middleware ...Middleware) {
+	s.httpConfig.use(middleware...)
+}
+
+// SetRateLimiter Limits the requests sent to a service, identified by its orid service, i.e. "sf"
+//
+// An empty service applies the limiter to every service without a limiter of its own, sharing one bucket
+// across them. Passing a nil limiter removes the limit.
+func (s *Sdk) SetRateLimiter(service string, limiter *RateLimiter) {
+	s.httpConfig.setRateLimiter(service, limiter)
+}
+
+// EnableCircuitBreaker Guards every service with a circuit breaker that fails fast while the service is down
+//
+// Each service has a breaker of its own, even when several services share one gateway host. Passing nil
+// disables the circuit breakers.
+func (s *Sdk) EnableCircuitBreaker(opts *CircuitBreakerOptions) {
+	if opts == nil {
+		s.httpConfig.setBreakers(nil)
+		return
+	}
+	s.httpConfig.setBreakers(newCircuitBreakers(opts))
+}
+
+// CircuitBreakerState Gets the state of the circuit breaker guarding a service, identified by its orid service,
+// i.e. "sf"
+func (s *Sdk) CircuitBreakerState(service string) CircuitState {
+	breakers := s.httpConfig.currentBreakers()
+	if breakers == nil {
+		return CircuitClosed
+	}
+	return breakers.state(service)
+}
+
 // GetServerlessFunctionsClient Gets a new serverless function client
 func (s *Sdk) GetServerlessFunctionsClient() *ServerlessFunctionsClient {
 	return &ServerlessFunctionsClient{
 		serviceURL:  s.sfURL,
 		authManager: s.defaultAuthManager,
+		httpConfig:  s.httpConfig,
 	}
 }
 
@@ -67,6 +129,7 @@ func (s *Sdk) GetIdentityClient() *IdentityClient {
 		allowSelfSignCert: s.allowSelfCert,
 		authManager:       s.defaultAuthManager,
 		identityURL:       s.identityURL,
+		httpConfig:        s.httpConfig,
 	}
 }
 
@@ -75,6 +138,7 @@ func (s *Sdk) GetQueueServiceClient() *QueueServiceClient {
 	return &QueueServiceClient{
 		authManager:     s.defaultAuthManager,
 		queueServiceURL: s.qsURL,
+		httpConfig:      s.httpConfig,
 	}
 }
 
@@ -83,6 +147,7 @@ func (s *Sdk) GetFileServiceClient() *FileServiceClient {
 	return &FileServiceClient{
 		authManager:    s.defaultAuthManager,
 		fileServiceURL: s.fsURL,
+		httpConfig:     s.httpConfig,
 	}
 }
 
@@ -91,5 +156,6 @@ func (s *Sdk) GetStateMachineServiceClient() *StateMachineServiceClient {
 	return &StateMachineServiceClient{
 		authManager:            s.defaultAuthManager,
 		stateMachineServiceURL: s.smURL,
+		httpConfig:             s.httpConfig,
 	}
 }
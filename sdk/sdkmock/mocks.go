@@ -0,0 +1,853 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=sdkmock/mocks.go -package=sdkmock
+//
+
+// Package sdkmock is a generated GoMock package.
+package sdkmock
+
+import (
+	context "context"
+	io "io"
+	iter "iter"
+	reflect "reflect"
+	time "time"
+
+	sdk "github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdentityAPI is a mock of IdentityAPI interface.
+type MockIdentityAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityAPIMockRecorder
+}
+
+// MockIdentityAPIMockRecorder is the mock recorder for MockIdentityAPI.
+type MockIdentityAPIMockRecorder struct {
+	mock *MockIdentityAPI
+}
+
+// NewMockIdentityAPI creates a new mock instance.
+func NewMockIdentityAPI(ctrl *gomock.Controller) *MockIdentityAPI {
+	mock := &MockIdentityAPI{ctrl: ctrl}
+	mock.recorder = &MockIdentityAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityAPI) EXPECT() *MockIdentityAPIMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIdentityAPI) Authenticate(data *sdk.AuthenticateArgs) (*sdk.AuthenticateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", data)
+	ret0, _ := ret[0].(*sdk.AuthenticateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIdentityAPIMockRecorder) Authenticate(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIdentityAPI)(nil).Authenticate), data)
+}
+
+// GetPublicSignature mocks base method.
+func (m *MockIdentityAPI) GetPublicSignature() (*sdk.PublicSignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicSignature")
+	ret0, _ := ret[0].(*sdk.PublicSignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicSignature indicates an expected call of GetPublicSignature.
+func (mr *MockIdentityAPIMockRecorder) GetPublicSignature() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicSignature", reflect.TypeOf((*MockIdentityAPI)(nil).GetPublicSignature))
+}
+
+// ImpersonateUser mocks base method.
+func (m *MockIdentityAPI) ImpersonateUser(data *sdk.ImpersonateUserArgs) (*sdk.ImpersonateUserResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImpersonateUser", data)
+	ret0, _ := ret[0].(*sdk.ImpersonateUserResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImpersonateUser indicates an expected call of ImpersonateUser.
+func (mr *MockIdentityAPIMockRecorder) ImpersonateUser(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImpersonateUser", reflect.TypeOf((*MockIdentityAPI)(nil).ImpersonateUser), data)
+}
+
+// Register mocks base method.
+func (m *MockIdentityAPI) Register(data *sdk.RegisterAccountArgs) (*sdk.RegisterResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", data)
+	ret0, _ := ret[0].(*sdk.RegisterResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockIdentityAPIMockRecorder) Register(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIdentityAPI)(nil).Register), data)
+}
+
+// UpdateUser mocks base method.
+func (m *MockIdentityAPI) UpdateUser(data *sdk.UpdateUserArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockIdentityAPIMockRecorder) UpdateUser(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIdentityAPI)(nil).UpdateUser), data)
+}
+
+// WithContext mocks base method.
+func (m *MockIdentityAPI) WithContext(ctx context.Context) sdk.IdentityAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(sdk.IdentityAPI)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockIdentityAPIMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockIdentityAPI)(nil).WithContext), ctx)
+}
+
+// MockQueueServiceAPI is a mock of QueueServiceAPI interface.
+type MockQueueServiceAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueServiceAPIMockRecorder
+}
+
+// MockQueueServiceAPIMockRecorder is the mock recorder for MockQueueServiceAPI.
+type MockQueueServiceAPIMockRecorder struct {
+	mock *MockQueueServiceAPI
+}
+
+// NewMockQueueServiceAPI creates a new mock instance.
+func NewMockQueueServiceAPI(ctrl *gomock.Controller) *MockQueueServiceAPI {
+	mock := &MockQueueServiceAPI{ctrl: ctrl}
+	mock.recorder = &MockQueueServiceAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueServiceAPI) EXPECT() *MockQueueServiceAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateQueue mocks base method.
+func (m *MockQueueServiceAPI) CreateQueue(data *sdk.CreateQueueArgs) (*sdk.CreateQueueResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueue", data)
+	ret0, _ := ret[0].(*sdk.CreateQueueResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQueue indicates an expected call of CreateQueue.
+func (mr *MockQueueServiceAPIMockRecorder) CreateQueue(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueue", reflect.TypeOf((*MockQueueServiceAPI)(nil).CreateQueue), data)
+}
+
+// DeleteQueue mocks base method.
+func (m *MockQueueServiceAPI) DeleteQueue(data *sdk.DeleteQueueArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueue", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQueue indicates an expected call of DeleteQueue.
+func (mr *MockQueueServiceAPIMockRecorder) DeleteQueue(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueue", reflect.TypeOf((*MockQueueServiceAPI)(nil).DeleteQueue), data)
+}
+
+// GetQueueDetails mocks base method.
+func (m *MockQueueServiceAPI) GetQueueDetails(data *sdk.GetQueueDetailsArgs) (*sdk.GetQueueDetailsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueDetails", data)
+	ret0, _ := ret[0].(*sdk.GetQueueDetailsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueDetails indicates an expected call of GetQueueDetails.
+func (mr *MockQueueServiceAPIMockRecorder) GetQueueDetails(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueDetails", reflect.TypeOf((*MockQueueServiceAPI)(nil).GetQueueDetails), data)
+}
+
+// UpdateQueue mocks base method.
+func (m *MockQueueServiceAPI) UpdateQueue(data *sdk.UpdateQueueArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQueue", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQueue indicates an expected call of UpdateQueue.
+func (mr *MockQueueServiceAPIMockRecorder) UpdateQueue(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQueue", reflect.TypeOf((*MockQueueServiceAPI)(nil).UpdateQueue), data)
+}
+
+// WithContext mocks base method.
+func (m *MockQueueServiceAPI) WithContext(ctx context.Context) sdk.QueueServiceAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(sdk.QueueServiceAPI)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockQueueServiceAPIMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockQueueServiceAPI)(nil).WithContext), ctx)
+}
+
+// MockFileServiceAPI is a mock of FileServiceAPI interface.
+type MockFileServiceAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileServiceAPIMockRecorder
+}
+
+// MockFileServiceAPIMockRecorder is the mock recorder for MockFileServiceAPI.
+type MockFileServiceAPIMockRecorder struct {
+	mock *MockFileServiceAPI
+}
+
+// NewMockFileServiceAPI creates a new mock instance.
+func NewMockFileServiceAPI(ctrl *gomock.Controller) *MockFileServiceAPI {
+	mock := &MockFileServiceAPI{ctrl: ctrl}
+	mock.recorder = &MockFileServiceAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileServiceAPI) EXPECT() *MockFileServiceAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateContainer mocks base method.
+func (m *MockFileServiceAPI) CreateContainer(data *sdk.CreateContainerArgs) (*sdk.CreateContainerResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContainer", data)
+	ret0, _ := ret[0].(*sdk.CreateContainerResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContainer indicates an expected call of CreateContainer.
+func (mr *MockFileServiceAPIMockRecorder) CreateContainer(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContainer", reflect.TypeOf((*MockFileServiceAPI)(nil).CreateContainer), data)
+}
+
+// DeleteContainerOrPath mocks base method.
+func (m *MockFileServiceAPI) DeleteContainerOrPath(data *sdk.DeleteContainerArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContainerOrPath", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContainerOrPath indicates an expected call of DeleteContainerOrPath.
+func (mr *MockFileServiceAPIMockRecorder) DeleteContainerOrPath(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContainerOrPath", reflect.TypeOf((*MockFileServiceAPI)(nil).DeleteContainerOrPath), data)
+}
+
+// DownloadFile mocks base method.
+func (m *MockFileServiceAPI) DownloadFile(fileOrid string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadFile", fileOrid, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockFileServiceAPIMockRecorder) DownloadFile(fileOrid, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockFileServiceAPI)(nil).DownloadFile), fileOrid, w)
+}
+
+// ListContainerContents mocks base method.
+func (m *MockFileServiceAPI) ListContainerContents(data *sdk.ListContainerContentsArgs) (*sdk.ListContainerContentsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContainerContents", data)
+	ret0, _ := ret[0].(*sdk.ListContainerContentsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContainerContents indicates an expected call of ListContainerContents.
+func (mr *MockFileServiceAPIMockRecorder) ListContainerContents(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContainerContents", reflect.TypeOf((*MockFileServiceAPI)(nil).ListContainerContents), data)
+}
+
+// ListContainers mocks base method.
+func (m *MockFileServiceAPI) ListContainers() ([]sdk.ContainerSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContainers")
+	ret0, _ := ret[0].([]sdk.ContainerSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContainers indicates an expected call of ListContainers.
+func (mr *MockFileServiceAPIMockRecorder) ListContainers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContainers", reflect.TypeOf((*MockFileServiceAPI)(nil).ListContainers))
+}
+
+// SyncDown mocks base method.
+func (m *MockFileServiceAPI) SyncDown(containerOrid, localDir string, opts *sdk.SyncOptions) (*sdk.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncDown", containerOrid, localDir, opts)
+	ret0, _ := ret[0].(*sdk.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncDown indicates an expected call of SyncDown.
+func (mr *MockFileServiceAPIMockRecorder) SyncDown(containerOrid, localDir, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncDown", reflect.TypeOf((*MockFileServiceAPI)(nil).SyncDown), containerOrid, localDir, opts)
+}
+
+// SyncUp mocks base method.
+func (m *MockFileServiceAPI) SyncUp(localDir, containerOrid string, opts *sdk.SyncOptions) (*sdk.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncUp", localDir, containerOrid, opts)
+	ret0, _ := ret[0].(*sdk.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncUp indicates an expected call of SyncUp.
+func (mr *MockFileServiceAPIMockRecorder) SyncUp(localDir, containerOrid, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUp", reflect.TypeOf((*MockFileServiceAPI)(nil).SyncUp), localDir, containerOrid, opts)
+}
+
+// UploadFile mocks base method.
+func (m *MockFileServiceAPI) UploadFile(data *sdk.UploadFileArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockFileServiceAPIMockRecorder) UploadFile(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockFileServiceAPI)(nil).UploadFile), data)
+}
+
+// WalkContainer mocks base method.
+func (m *MockFileServiceAPI) WalkContainer(rootOrid string, fn sdk.WalkContainerFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkContainer", rootOrid, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalkContainer indicates an expected call of WalkContainer.
+func (mr *MockFileServiceAPIMockRecorder) WalkContainer(rootOrid, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkContainer", reflect.TypeOf((*MockFileServiceAPI)(nil).WalkContainer), rootOrid, fn)
+}
+
+// WithContext mocks base method.
+func (m *MockFileServiceAPI) WithContext(ctx context.Context) sdk.FileServiceAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(sdk.FileServiceAPI)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockFileServiceAPIMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockFileServiceAPI)(nil).WithContext), ctx)
+}
+
+// MockStateMachineServiceAPI is a mock of StateMachineServiceAPI interface.
+type MockStateMachineServiceAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateMachineServiceAPIMockRecorder
+}
+
+// MockStateMachineServiceAPIMockRecorder is the mock recorder for MockStateMachineServiceAPI.
+type MockStateMachineServiceAPIMockRecorder struct {
+	mock *MockStateMachineServiceAPI
+}
+
+// NewMockStateMachineServiceAPI creates a new mock instance.
+func NewMockStateMachineServiceAPI(ctrl *gomock.Controller) *MockStateMachineServiceAPI {
+	mock := &MockStateMachineServiceAPI{ctrl: ctrl}
+	mock.recorder = &MockStateMachineServiceAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStateMachineServiceAPI) EXPECT() *MockStateMachineServiceAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateStateMachine mocks base method.
+func (m *MockStateMachineServiceAPI) CreateStateMachine(data *sdk.CreateStateMachineArgs) (*sdk.CreateStateMachineResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStateMachine", data)
+	ret0, _ := ret[0].(*sdk.CreateStateMachineResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStateMachine indicates an expected call of CreateStateMachine.
+func (mr *MockStateMachineServiceAPIMockRecorder) CreateStateMachine(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStateMachine", reflect.TypeOf((*MockStateMachineServiceAPI)(nil).CreateStateMachine), data)
+}
+
+// DeleteStateMachine mocks base method.
+func (m *MockStateMachineServiceAPI) DeleteStateMachine(data *sdk.DeleteStateMachineArgs) (*sdk.DeleteStateMachineResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStateMachine", data)
+	ret0, _ := ret[0].(*sdk.DeleteStateMachineResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStateMachine indicates an expected call of DeleteStateMachine.
+func (mr *MockStateMachineServiceAPIMockRecorder) DeleteStateMachine(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStateMachine", reflect.TypeOf((*MockStateMachineServiceAPI)(nil).DeleteStateMachine), data)
+}
+
+// GetStateMachineDetails mocks base method.
+func (m *MockStateMachineServiceAPI) GetStateMachineDetails(data *sdk.GetStateMachineDetailsArgs) (*sdk.GetStateMachineDetailsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStateMachineDetails", data)
+	ret0, _ := ret[0].(*sdk.GetStateMachineDetailsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateMachineDetails indicates an expected call of GetStateMachineDetails.
+func (mr *MockStateMachineServiceAPIMockRecorder) GetStateMachineDetails(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateMachineDetails", reflect.TypeOf((*MockStateMachineServiceAPI)(nil).GetStateMachineDetails), data)
+}
+
+// UpdateStateMachine mocks base method.
+func (m *MockStateMachineServiceAPI) UpdateStateMachine(data *sdk.UpdateStateMachineArgs) (*sdk.UpdateStateMachineResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStateMachine", data)
+	ret0, _ := ret[0].(*sdk.UpdateStateMachineResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStateMachine indicates an expected call of UpdateStateMachine.
+func (mr *MockStateMachineServiceAPIMockRecorder) UpdateStateMachine(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStateMachine", reflect.TypeOf((*MockStateMachineServiceAPI)(nil).UpdateStateMachine), data)
+}
+
+// WithContext mocks base method.
+func (m *MockStateMachineServiceAPI) WithContext(ctx context.Context) sdk.StateMachineServiceAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(sdk.StateMachineServiceAPI)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockStateMachineServiceAPIMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockStateMachineServiceAPI)(nil).WithContext), ctx)
+}
+
+// MockServerlessFunctionsAPI is a mock of ServerlessFunctionsAPI interface.
+type MockServerlessFunctionsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockServerlessFunctionsAPIMockRecorder
+}
+
+// MockServerlessFunctionsAPIMockRecorder is the mock recorder for MockServerlessFunctionsAPI.
+type MockServerlessFunctionsAPIMockRecorder struct {
+	mock *MockServerlessFunctionsAPI
+}
+
+// NewMockServerlessFunctionsAPI creates a new mock instance.
+func NewMockServerlessFunctionsAPI(ctrl *gomock.Controller) *MockServerlessFunctionsAPI {
+	mock := &MockServerlessFunctionsAPI{ctrl: ctrl}
+	mock.recorder = &MockServerlessFunctionsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServerlessFunctionsAPI) EXPECT() *MockServerlessFunctionsAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateFunction mocks base method.
+func (m *MockServerlessFunctionsAPI) CreateFunction(name string) (*sdk.ServerlessFunctionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFunction", name)
+	ret0, _ := ret[0].(*sdk.ServerlessFunctionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFunction indicates an expected call of CreateFunction.
+func (mr *MockServerlessFunctionsAPIMockRecorder) CreateFunction(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFunction", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).CreateFunction), name)
+}
+
+// CreateFunctionAlias mocks base method.
+func (m *MockServerlessFunctionsAPI) CreateFunctionAlias(functionOrid, name, version string) (*sdk.FunctionAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFunctionAlias", functionOrid, name, version)
+	ret0, _ := ret[0].(*sdk.FunctionAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFunctionAlias indicates an expected call of CreateFunctionAlias.
+func (mr *MockServerlessFunctionsAPIMockRecorder) CreateFunctionAlias(functionOrid, name, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFunctionAlias", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).CreateFunctionAlias), functionOrid, name, version)
+}
+
+// DeleteFunction mocks base method.
+func (m *MockServerlessFunctionsAPI) DeleteFunction(functionOrid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunction", functionOrid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFunction indicates an expected call of DeleteFunction.
+func (mr *MockServerlessFunctionsAPIMockRecorder) DeleteFunction(functionOrid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunction", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).DeleteFunction), functionOrid)
+}
+
+// DeleteFunctionAlias mocks base method.
+func (m *MockServerlessFunctionsAPI) DeleteFunctionAlias(functionOrid, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionAlias", functionOrid, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFunctionAlias indicates an expected call of DeleteFunctionAlias.
+func (mr *MockServerlessFunctionsAPIMockRecorder) DeleteFunctionAlias(functionOrid, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionAlias", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).DeleteFunctionAlias), functionOrid, name)
+}
+
+// GetFunctionDetails mocks base method.
+func (m *MockServerlessFunctionsAPI) GetFunctionDetails(functionOrid string) (*sdk.ServerlessFunctionDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionDetails", functionOrid)
+	ret0, _ := ret[0].(*sdk.ServerlessFunctionDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionDetails indicates an expected call of GetFunctionDetails.
+func (mr *MockServerlessFunctionsAPIMockRecorder) GetFunctionDetails(functionOrid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionDetails", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).GetFunctionDetails), functionOrid)
+}
+
+// GetFunctionLogs mocks base method.
+func (m *MockServerlessFunctionsAPI) GetFunctionLogs(functionOrid string, since time.Time, limit int) ([]sdk.FunctionLogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionLogs", functionOrid, since, limit)
+	ret0, _ := ret[0].([]sdk.FunctionLogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionLogs indicates an expected call of GetFunctionLogs.
+func (mr *MockServerlessFunctionsAPIMockRecorder) GetFunctionLogs(functionOrid, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionLogs", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).GetFunctionLogs), functionOrid, since, limit)
+}
+
+// GetInvocationResult mocks base method.
+func (m *MockServerlessFunctionsAPI) GetInvocationResult(invocationID string) (*sdk.InvocationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvocationResult", invocationID)
+	ret0, _ := ret[0].(*sdk.InvocationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvocationResult indicates an expected call of GetInvocationResult.
+func (mr *MockServerlessFunctionsAPIMockRecorder) GetInvocationResult(invocationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvocationResult", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).GetInvocationResult), invocationID)
+}
+
+// InvokeFunction mocks base method.
+func (m *MockServerlessFunctionsAPI) InvokeFunction(functionOrid string, body any) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeFunction", functionOrid, body)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeFunction indicates an expected call of InvokeFunction.
+func (mr *MockServerlessFunctionsAPIMockRecorder) InvokeFunction(functionOrid, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeFunction", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).InvokeFunction), functionOrid, body)
+}
+
+// InvokeFunctionAsync mocks base method.
+func (m *MockServerlessFunctionsAPI) InvokeFunctionAsync(functionOrid string, input any) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeFunctionAsync", functionOrid, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeFunctionAsync indicates an expected call of InvokeFunctionAsync.
+func (mr *MockServerlessFunctionsAPIMockRecorder) InvokeFunctionAsync(functionOrid, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeFunctionAsync", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).InvokeFunctionAsync), functionOrid, input)
+}
+
+// InvokeFunctionInto mocks base method.
+func (m *MockServerlessFunctionsAPI) InvokeFunctionInto(functionOrid string, input, out any) (*sdk.InvocationMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeFunctionInto", functionOrid, input, out)
+	ret0, _ := ret[0].(*sdk.InvocationMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeFunctionInto indicates an expected call of InvokeFunctionInto.
+func (mr *MockServerlessFunctionsAPIMockRecorder) InvokeFunctionInto(functionOrid, input, out any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeFunctionInto", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).InvokeFunctionInto), functionOrid, input, out)
+}
+
+// InvokeFunctionVersion mocks base method.
+func (m *MockServerlessFunctionsAPI) InvokeFunctionVersion(functionOrid, qualifier string, input, out any) (*sdk.InvocationMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeFunctionVersion", functionOrid, qualifier, input, out)
+	ret0, _ := ret[0].(*sdk.InvocationMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeFunctionVersion indicates an expected call of InvokeFunctionVersion.
+func (mr *MockServerlessFunctionsAPIMockRecorder) InvokeFunctionVersion(functionOrid, qualifier, input, out any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeFunctionVersion", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).InvokeFunctionVersion), functionOrid, qualifier, input, out)
+}
+
+// IterateFunctions mocks base method.
+func (m *MockServerlessFunctionsAPI) IterateFunctions(opts *sdk.ListFunctionsOptions) iter.Seq2[sdk.ServerlessFunctionSummary, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateFunctions", opts)
+	ret0, _ := ret[0].(iter.Seq2[sdk.ServerlessFunctionSummary, error])
+	return ret0
+}
+
+// IterateFunctions indicates an expected call of IterateFunctions.
+func (mr *MockServerlessFunctionsAPIMockRecorder) IterateFunctions(opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateFunctions", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).IterateFunctions), opts)
+}
+
+// ListFunctionAliases mocks base method.
+func (m *MockServerlessFunctionsAPI) ListFunctionAliases(functionOrid string) ([]sdk.FunctionAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionAliases", functionOrid)
+	ret0, _ := ret[0].([]sdk.FunctionAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionAliases indicates an expected call of ListFunctionAliases.
+func (mr *MockServerlessFunctionsAPIMockRecorder) ListFunctionAliases(functionOrid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionAliases", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).ListFunctionAliases), functionOrid)
+}
+
+// ListFunctionVersions mocks base method.
+func (m *MockServerlessFunctionsAPI) ListFunctionVersions(functionOrid string) ([]sdk.FunctionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionVersions", functionOrid)
+	ret0, _ := ret[0].([]sdk.FunctionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionVersions indicates an expected call of ListFunctionVersions.
+func (mr *MockServerlessFunctionsAPIMockRecorder) ListFunctionVersions(functionOrid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionVersions", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).ListFunctionVersions), functionOrid)
+}
+
+// ListFunctions mocks base method.
+func (m *MockServerlessFunctionsAPI) ListFunctions() (*[]sdk.ServerlessFunctionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctions")
+	ret0, _ := ret[0].(*[]sdk.ServerlessFunctionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctions indicates an expected call of ListFunctions.
+func (mr *MockServerlessFunctionsAPIMockRecorder) ListFunctions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctions", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).ListFunctions))
+}
+
+// ListFunctionsPage mocks base method.
+func (m *MockServerlessFunctionsAPI) ListFunctionsPage(opts *sdk.ListFunctionsOptions, pageToken string) (*sdk.FunctionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionsPage", opts, pageToken)
+	ret0, _ := ret[0].(*sdk.FunctionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionsPage indicates an expected call of ListFunctionsPage.
+func (mr *MockServerlessFunctionsAPIMockRecorder) ListFunctionsPage(opts, pageToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsPage", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).ListFunctionsPage), opts, pageToken)
+}
+
+// TailFunctionLogs mocks base method.
+func (m *MockServerlessFunctionsAPI) TailFunctionLogs(functionOrid string, since time.Time, opts *sdk.WaitOptions) iter.Seq2[sdk.FunctionLogEntry, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TailFunctionLogs", functionOrid, since, opts)
+	ret0, _ := ret[0].(iter.Seq2[sdk.FunctionLogEntry, error])
+	return ret0
+}
+
+// TailFunctionLogs indicates an expected call of TailFunctionLogs.
+func (mr *MockServerlessFunctionsAPIMockRecorder) TailFunctionLogs(functionOrid, since, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailFunctionLogs", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).TailFunctionLogs), functionOrid, since, opts)
+}
+
+// UpdateFunctionAlias mocks base method.
+func (m *MockServerlessFunctionsAPI) UpdateFunctionAlias(functionOrid, name, version string) (*sdk.FunctionAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionAlias", functionOrid, name, version)
+	ret0, _ := ret[0].(*sdk.FunctionAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionAlias indicates an expected call of UpdateFunctionAlias.
+func (mr *MockServerlessFunctionsAPIMockRecorder) UpdateFunctionAlias(functionOrid, name, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionAlias", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).UpdateFunctionAlias), functionOrid, name, version)
+}
+
+// UpdateFunctionCode mocks base method.
+func (m *MockServerlessFunctionsAPI) UpdateFunctionCode(data *sdk.UpdateFunctionCodeArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionCode", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFunctionCode indicates an expected call of UpdateFunctionCode.
+func (mr *MockServerlessFunctionsAPIMockRecorder) UpdateFunctionCode(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionCode", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).UpdateFunctionCode), data)
+}
+
+// UpdateFunctionConfiguration mocks base method.
+func (m *MockServerlessFunctionsAPI) UpdateFunctionConfiguration(data *sdk.UpdateFunctionConfigurationArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionConfiguration", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFunctionConfiguration indicates an expected call of UpdateFunctionConfiguration.
+func (mr *MockServerlessFunctionsAPIMockRecorder) UpdateFunctionConfiguration(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionConfiguration", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).UpdateFunctionConfiguration), data)
+}
+
+// WaitForFunctionReady mocks base method.
+func (m *MockServerlessFunctionsAPI) WaitForFunctionReady(functionOrid string, opts *sdk.WaitOptions) (*sdk.ServerlessFunctionDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForFunctionReady", functionOrid, opts)
+	ret0, _ := ret[0].(*sdk.ServerlessFunctionDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForFunctionReady indicates an expected call of WaitForFunctionReady.
+func (mr *MockServerlessFunctionsAPIMockRecorder) WaitForFunctionReady(functionOrid, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForFunctionReady", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).WaitForFunctionReady), functionOrid, opts)
+}
+
+// WaitForInvocation mocks base method.
+func (m *MockServerlessFunctionsAPI) WaitForInvocation(invocationID string, opts *sdk.WaitOptions) (*sdk.InvocationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForInvocation", invocationID, opts)
+	ret0, _ := ret[0].(*sdk.InvocationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForInvocation indicates an expected call of WaitForInvocation.
+func (mr *MockServerlessFunctionsAPIMockRecorder) WaitForInvocation(invocationID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForInvocation", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).WaitForInvocation), invocationID, opts)
+}
+
+// WithContext mocks base method.
+func (m *MockServerlessFunctionsAPI) WithContext(ctx context.Context) sdk.ServerlessFunctionsAPI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(sdk.ServerlessFunctionsAPI)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockServerlessFunctionsAPIMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockServerlessFunctionsAPI)(nil).WithContext), ctx)
+}
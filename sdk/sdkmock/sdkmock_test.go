@@ -0,0 +1,52 @@
+package sdkmock_test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk"
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdkmock"
+)
+
+// The generated mocks must keep implementing the interfaces they were generated from
+var (
+	_ sdk.IdentityAPI            = (*sdkmock.MockIdentityAPI)(nil)
+	_ sdk.QueueServiceAPI        = (*sdkmock.MockQueueServiceAPI)(nil)
+	_ sdk.FileServiceAPI         = (*sdkmock.MockFileServiceAPI)(nil)
+	_ sdk.StateMachineServiceAPI = (*sdkmock.MockStateMachineServiceAPI)(nil)
+	_ sdk.ServerlessFunctionsAPI = (*sdkmock.MockServerlessFunctionsAPI)(nil)
+)
+
+const functionOrid = "orid:1:mdsCloud:::1001:sf:greeter"
+
+type greeting struct {
+	Message string `json:"message"`
+}
+
+func TestMockServerlessFunctionsInvokeFunctionAs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := sdkmock.NewMockServerlessFunctionsAPI(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client.EXPECT().WithContext(ctx).Return(client)
+	client.EXPECT().
+		InvokeFunctionInto(functionOrid, "world", gomock.Any()).
+		DoAndReturn(func(_ string, _ interface{}, out interface{}) (*sdk.InvocationMetadata, error) {
+			out.(*greeting).Message = "hello world"
+			return &sdk.InvocationMetadata{InvocationID: "invocation-1"}, nil
+		})
+
+	result, metadata, err := sdk.InvokeFunctionAs[greeting](client.WithContext(ctx), functionOrid, "world")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result.Message != "hello world" {
+		t.Errorf("Message incorrect, got: %s", result.Message)
+	}
+	if metadata.InvocationID != "invocation-1" {
+		t.Errorf("Invocation id incorrect, got: %s", metadata.InvocationID)
+	}
+}
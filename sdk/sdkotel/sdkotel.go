@@ -0,0 +1,151 @@
+// Package sdkotel Provides OpenTelemetry tracing and metrics for the MDS Cloud SDK
+//
+// Register the middleware once on the SDK object and bind a context carrying the caller's span to a client
+// with WithContext so SDK spans join the caller's trace:
+//
+//	s.Use(sdkotel.Middleware())
+//	qs := s.GetQueueServiceClient().WithContext(ctx)
+package sdkotel
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk"
+)
+
+// InstrumentationName Name reported for the tracer and meter used by this package
+const InstrumentationName = "github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/sdkotel"
+
+// Attribute keys recorded on spans and metrics
+const (
+	ServiceKey    = attribute.Key("mds.service")
+	OperationKey  = attribute.Key("mds.operation")
+	OridKey       = attribute.Key("mds.orid")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option Configures the middleware created by Middleware
+type Option func(*config)
+
+// WithTracerProvider Uses the tracer provider instead of the global one
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider Uses the meter provider instead of the global one
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagator Uses the propagator instead of W3C trace context to inject headers into requests
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// Middleware Creates an sdk.Middleware recording a span, a latency histogram and an error counter for
+// every request made by the SDK. W3C trace context headers are injected into each outgoing request.
+func Middleware(opts ...Option) sdk.Middleware {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     propagation.TraceContext{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	tracer := c.tracerProvider.Tracer(InstrumentationName)
+	meter := c.meterProvider.Meter(InstrumentationName)
+
+	// NOTE: Instrument creation only fails for invalid names; the no-op instruments returned alongside the
+	// error remain safe to use.
+	duration, err := meter.Float64Histogram(
+		"mds.client.request.duration",
+		metric.WithDescription("Duration of requests made to MDS Cloud services"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	failures, err := meter.Int64Counter(
+		"mds.client.request.errors",
+		metric.WithDescription("Number of MDS Cloud requests that failed or returned an error status"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next sdk.RoundTrip) sdk.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			service := sdk.RequestService(req)
+			operation := sdk.RequestOperation(req)
+			attrs := []attribute.KeyValue{
+				ServiceKey.String(service),
+				OperationKey.String(operation),
+				MethodKey.String(req.Method),
+			}
+
+			spanAttrs := attrs
+			if resourceOrid := sdk.RequestOrid(req); resourceOrid != "" {
+				spanAttrs = append(spanAttrs, OridKey.String(resourceOrid))
+			}
+
+			ctx, span := tracer.Start(
+				req.Context(),
+				service+" "+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(spanAttrs...),
+			)
+			defer span.End()
+
+			req = req.Clone(ctx)
+			c.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			r, err := next(req)
+			elapsed := time.Since(start).Seconds()
+
+			failed := false
+			if err != nil {
+				failed = true
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				attrs = append(attrs, StatusCodeKey.Int(r.StatusCode))
+				span.SetAttributes(StatusCodeKey.Int(r.StatusCode))
+				if r.StatusCode >= 400 {
+					failed = true
+					span.SetStatus(codes.Error, http.StatusText(r.StatusCode))
+				}
+			}
+
+			duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+			if failed {
+				failures.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+
+			return r, err
+		}
+	}
+}
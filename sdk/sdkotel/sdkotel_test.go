@@ -0,0 +1,99 @@
+package sdkotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk"
+)
+
+func TestMiddlewareRecordsSpansAndMetrics(t *testing.T) {
+	traceParents := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents = append(traceParents, r.Header.Get("Traceparent"))
+		if r.URL.Path == "/v1/authenticate" {
+			w.Write([]byte(`{"token":"test-token"}`))
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	defer srv.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	s := sdk.NewSdk("1001", "user", "password", false, false, map[string]string{
+		"identityUrl": srv.URL,
+		"qsUrl":       srv.URL,
+	})
+	s.Use(Middleware(WithTracerProvider(tracerProvider), WithMeterProvider(meterProvider)))
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+	_, err := s.GetQueueServiceClient().WithContext(ctx).GetQueueDetails(&sdk.GetQueueDetailsArgs{
+		Orid: "orid:1:mdsCloud:::1001:qs:testQueue",
+	})
+	parent.End()
+	if err == nil {
+		t.Fatalf("Expected error for 404 response")
+	}
+
+	if len(traceParents) != 2 || traceParents[1] == "" {
+		t.Fatalf("Expected trace context header on requests, got: %v", traceParents)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("Expected 3 spans, got: %d", len(ended))
+	}
+
+	if authSpan := ended[0]; authSpan.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Expected authenticate span to be a child of the caller span")
+	}
+
+	queueSpan := ended[1]
+	if queueSpan.Name() != "qs GetQueueDetails" {
+		t.Errorf("Span name incorrect, got: %s", queueSpan.Name())
+	}
+	if queueSpan.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Expected queue span to be a child of the caller span")
+	}
+
+	attrs := map[string]string{}
+	for _, attr := range queueSpan.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	expected := map[string]string{
+		"mds.service":               "qs",
+		"mds.operation":             "GetQueueDetails",
+		"mds.orid":                  "orid:1:mdsCloud:::1001:qs:testQueue",
+		"http.response.status_code": "404",
+	}
+	for key, value := range expected {
+		if attrs[key] != value {
+			t.Errorf("Attribute %s incorrect, got: %s, expected: %s", key, attrs[key], value)
+		}
+	}
+
+	data := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Unexpected error collecting metrics: %s", err)
+	}
+	names := map[string]bool{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			names[m.Name] = true
+		}
+	}
+	if !names["mds.client.request.duration"] || !names["mds.client.request.errors"] {
+		t.Errorf("Expected latency and error metrics, got: %v", names)
+	}
+}
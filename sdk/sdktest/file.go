@@ -0,0 +1,329 @@
+package sdktest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// File A file held within a file service container
+type File struct {
+	Path     string
+	Content  []byte
+	Modified time.Time
+}
+
+type container struct {
+	orid  string
+	name  string
+	files map[string]*File
+	dirs  map[string]bool
+}
+
+func (s *Server) serveFiles(w http.ResponseWriter, r *http.Request, accountID string) {
+	if name, ok := splitPath(r.URL.Path, "/v1/createContainer/"); ok && r.Method == "POST" {
+		s.createContainer(w, name, accountID)
+		return
+	}
+
+	if r.URL.Path == "/v1/containers" && r.Method == "GET" {
+		s.listContainers(w, accountID)
+		return
+	}
+
+	if value, ok := splitPath(r.URL.Path, "/v1/list/"); ok && r.Method == "GET" {
+		s.listContainer(w, r, value, accountID)
+		return
+	}
+
+	if value, ok := splitPath(r.URL.Path, "/v1/upload/"); ok && r.Method == "POST" {
+		s.uploadFile(w, r, value, accountID)
+		return
+	}
+
+	if value, ok := splitPath(r.URL.Path, "/v1/download/"); ok && r.Method == "GET" {
+		s.downloadFile(w, r, value, accountID)
+		return
+	}
+
+	if value, ok := splitPath(r.URL.Path, "/v1/"); ok && r.Method == "DELETE" {
+		s.deleteContainerOrPath(w, r, value, accountID)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+func (s *Server) createContainer(w http.ResponseWriter, name string, accountID string) {
+	if name == "" || strings.Contains(name, "/") {
+		writeError(w, http.StatusBadRequest, "invalid container name")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	containerOrid := newOrid(accountID, orid.ServiceFile, name)
+	if _, ok := s.files[containerOrid]; ok {
+		writeError(w, http.StatusConflict, "container already exists")
+		return
+	}
+
+	s.files[containerOrid] = &container{
+		orid:  containerOrid,
+		name:  name,
+		files: make(map[string]*File),
+		dirs:  make(map[string]bool),
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"orid": containerOrid})
+}
+
+func (s *Server) listContainers(w http.ResponseWriter, accountID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	containers := make([]map[string]string, 0)
+	for _, c := range s.files {
+		if o, err := orid.Parse(c.orid); err == nil && o.AccountID == accountID {
+			containers = append(containers, map[string]string{"name": c.name, "orid": c.orid})
+		}
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i]["name"] < containers[j]["name"]
+	})
+	writeJSON(w, http.StatusOK, containers)
+}
+
+// lookupContainer Resolves a file service orid to its container and cleaned sub path. Callers must hold the lock.
+func (s *Server) lookupContainer(value string, accountID string) (*container, string, bool) {
+	o, ok := resourceOrid(value, accountID, orid.ServiceFile)
+	if !ok {
+		return nil, "", false
+	}
+
+	c, ok := s.files[o.Root().String()]
+	if !ok {
+		return nil, "", false
+	}
+	return c, strings.Trim(path.Clean("/"+o.ResourceRider), "/"), true
+}
+
+func (s *Server) listContainer(w http.ResponseWriter, r *http.Request, value string, accountID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, dir, ok := s.lookupContainer(value, accountID)
+	if !ok || (dir != "" && !c.dirs[dir]) {
+		http.NotFound(w, r)
+		return
+	}
+
+	directories := make([]string, 0)
+	for d := range c.dirs {
+		if parentDir(d) == dir {
+			directories = append(directories, path.Base(d))
+		}
+	}
+	files := make([]string, 0)
+	for _, f := range c.files {
+		if parentDir(f.Path) == dir {
+			files = append(files, path.Base(f.Path))
+		}
+	}
+	sort.Strings(directories)
+	sort.Strings(files)
+
+	body := map[string]interface{}{"directories": directories, "files": files}
+	if r.URL.Query().Get("metadata") == "true" {
+		details := make([]map[string]interface{}, 0, len(files))
+		for _, name := range files {
+			f := c.files[path.Join(dir, name)]
+			details = append(details, map[string]interface{}{
+				"name":        name,
+				"size":        len(f.Content),
+				"modified":    f.Modified.UTC().Format(time.RFC3339Nano),
+				"contentType": contentType(f),
+				"checksum":    checksum(f.Content),
+			})
+		}
+		body["fileDetails"] = details
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) uploadFile(w http.ResponseWriter, r *http.Request, value string, accountID string) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid upload")
+		return
+	}
+
+	uploads := r.MultipartForm.File["file"]
+	if len(uploads) == 0 {
+		writeError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	contents := make(map[string][]byte, len(uploads))
+	for _, header := range uploads {
+		name := header.Filename
+		if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+			writeError(w, http.StatusBadRequest, "invalid file name")
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid upload")
+			return
+		}
+		contents[name], err = io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid upload")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, dir, ok := s.lookupContainer(value, accountID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if _, isFile := c.files[dir]; isFile {
+		writeError(w, http.StatusBadRequest, "upload target is a file")
+		return
+	}
+	for name := range contents {
+		if c.dirs[path.Join(dir, name)] {
+			writeError(w, http.StatusBadRequest, "upload target is a directory")
+			return
+		}
+	}
+	if dir != "" {
+		c.dirs[dir] = true
+	}
+	for name, content := range contents {
+		c.putFile(path.Join(dir, name), content, time.Now())
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "OK"})
+}
+
+func (s *Server) downloadFile(w http.ResponseWriter, r *http.Request, value string, accountID string) {
+	s.mu.Lock()
+	c, target, ok := s.lookupContainer(value, accountID)
+	var f *File
+	if ok {
+		f = c.files[target]
+	}
+	s.mu.Unlock()
+
+	if f == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", contentType(f))
+	w.WriteHeader(http.StatusOK)
+	w.Write(f.Content)
+}
+
+func (s *Server) deleteContainerOrPath(w http.ResponseWriter, r *http.Request, value string, accountID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, target, ok := s.lookupContainer(value, accountID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if target == "" {
+		delete(s.files, c.orid)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	_, isFile := c.files[target]
+	if !isFile && !c.dirs[target] {
+		http.NotFound(w, r)
+		return
+	}
+	for p := range c.files {
+		if p == target || strings.HasPrefix(p, target+"/") {
+			delete(c.files, p)
+		}
+	}
+	for d := range c.dirs {
+		if d == target || strings.HasPrefix(d, target+"/") {
+			delete(c.dirs, d)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// contentType Content type of the file guessed from its extension, or its content when the extension is unknown
+func contentType(f *File) string {
+	if t := mime.TypeByExtension(path.Ext(f.Path)); t != "" {
+		return t
+	}
+	return http.DetectContentType(f.Content)
+}
+
+// checksum Content hash of a file in the form "sha256:<hex>"
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// parentDir Directory holding the container relative path, empty for the container root
+func parentDir(p string) string {
+	if dir := path.Dir(p); dir != "." {
+		return dir
+	}
+	return ""
+}
+
+// putFile Stores the file, creating any parent directories. Callers must hold the lock.
+func (c *container) putFile(filePath string, content []byte, modified time.Time) {
+	filePath = strings.Trim(path.Clean("/"+filePath), "/")
+	for dir := path.Dir(filePath); dir != "."; dir = path.Dir(dir) {
+		c.dirs[dir] = true
+	}
+	c.files[filePath] = &File{Path: filePath, Content: content, Modified: modified}
+}
+
+// PutFile Stores a file within a container, creating any parent directories. Returns false when the
+// container does not exist.
+func (s *Server) PutFile(containerOrid string, filePath string, content []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.files[containerOrid]
+	if !ok {
+		return false
+	}
+	c.putFile(filePath, content, time.Now())
+	return true
+}
+
+// Files Returns copies of the files held within a container, keyed by path
+func (s *Server) Files(containerOrid string) map[string]File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files := make(map[string]File)
+	if c, ok := s.files[containerOrid]; ok {
+		for p, f := range c.files {
+			files[p] = *f
+		}
+	}
+	return files
+}
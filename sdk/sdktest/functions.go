@@ -0,0 +1,441 @@
+package sdktest
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// Function A serverless function held by the server
+type Function struct {
+	Orid           string
+	Name           string
+	Version        int
+	Runtime        string
+	EntryPoint     string
+	Context        string
+	Source         []byte
+	SourceHash     string
+	Environment    map[string]string
+	MemorySize     int
+	TimeoutSeconds int
+	Tags           map[string]string
+	Versions       []FunctionVersion
+	Aliases        map[string]int
+	BuildStatus    string
+	BuildLogs      string
+	Created        time.Time
+	LastUpdate     time.Time
+	LastInvoke     time.Time
+}
+
+// InvokeHandler Produces the result of invoking a function. Returned errors are sent to the caller as a 400
+// flagged as a function error.
+type InvokeHandler func(function Function, body []byte) ([]byte, error)
+
+// SetAsyncBuilds Leaves uploaded code in the "building" status until SetFunctionBuild completes the build
+func (s *Server) SetAsyncBuilds(async bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.asyncBuilds = async
+}
+
+// SetFunctionBuild Sets the build status and logs reported for a function. Returns false when the function
+// does not exist.
+func (s *Server) SetFunctionBuild(functionOrid string, status string, logs string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.funcs[functionOrid]
+	if !ok {
+		return false
+	}
+	f.BuildStatus = status
+	f.BuildLogs = logs
+	return true
+}
+
+// SetInvocationLogs Sets the logs reported with every invocation result
+func (s *Server) SetInvocationLogs(logs string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invocationLogs = logs
+}
+
+// SetInvokeHandler Replaces how functions are invoked. By default the request body is echoed back.
+func (s *Server) SetInvokeHandler(handler InvokeHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invokeHandler = handler
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (s *Server) serveFunctions(w http.ResponseWriter, r *http.Request, accountID string) {
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/v1/create":
+		s.createFunction(w, r, accountID)
+		return
+	case r.Method == "GET" && r.URL.Path == "/v1/list":
+		s.listFunctions(w, r, accountID)
+		return
+	}
+	if invocationID, ok := splitPath(r.URL.Path, "/v1/invocations/"); ok && r.Method == "GET" {
+		s.getInvocation(w, r, accountID, invocationID)
+		return
+	}
+
+	var route, value string
+	for _, prefix := range []string{"invoke", "inspect", "uploadCode", "configuration", "versions", "aliases", "logs"} {
+		if rest, ok := splitPath(r.URL.Path, "/v1/"+prefix+"/"); ok {
+			route, value = prefix, rest
+			break
+		}
+	}
+	if route == "" {
+		value, _ = splitPath(r.URL.Path, "/v1/")
+	}
+	var aliasName string
+	if route == "aliases" {
+		if i := strings.LastIndex(value, "/"); i >= 0 {
+			value, aliasName = value[:i], value[i+1:]
+		}
+	}
+
+	if _, ok := resourceOrid(value, accountID, orid.ServiceServerlessFunctions); !ok {
+		writeError(w, http.StatusBadRequest, "invalid function orid")
+		return
+	}
+
+	switch {
+	case route == "invoke" && r.Method == "POST":
+		s.invokeFunction(w, r, value)
+	case route == "inspect" && r.Method == "GET":
+		s.inspectFunction(w, r, value)
+	case route == "uploadCode" && r.Method == "POST":
+		s.uploadFunctionCode(w, r, value)
+	case route == "configuration" && r.Method == "POST":
+		s.updateFunctionConfiguration(w, r, value)
+	case route == "logs" && r.Method == "GET":
+		s.listFunctionLogs(w, r, value)
+	case route == "versions" && r.Method == "GET":
+		s.listFunctionVersions(w, r, value)
+	case route == "aliases":
+		s.serveFunctionAliases(w, r, value, aliasName)
+	case route == "" && r.Method == "DELETE":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.funcs[value]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(s.funcs, value)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) createFunction(w http.ResponseWriter, r *http.Request, accountID string) {
+	body := struct {
+		Name string `json:"name"`
+	}{}
+	if err := readJSON(r, &body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range s.funcs {
+		if f.Name == body.Name {
+			writeError(w, http.StatusConflict, "function already exists")
+			return
+		}
+	}
+
+	functionOrid := newOrid(accountID, orid.ServiceServerlessFunctions, newID())
+	s.funcs[functionOrid] = &Function{
+		Orid:    functionOrid,
+		Name:    body.Name,
+		Created: time.Now(),
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"name": body.Name, "orid": functionOrid})
+}
+
+// listFunctions Lists the account's functions filtered by the namePrefix and runtime query parameters. When a
+// limit or nextToken is given a page of results is returned along with the token of the next page.
+func (s *Server) listFunctions(w http.ResponseWriter, r *http.Request, accountID string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	functions := make([]map[string]string, 0)
+	for _, f := range s.sortedFunctions(accountID) {
+		if !strings.HasPrefix(f.Name, query.Get("namePrefix")) {
+			continue
+		}
+		if runtime := query.Get("runtime"); runtime != "" && f.Runtime != runtime {
+			continue
+		}
+		functions = append(functions, map[string]string{"name": f.Name, "orid": f.Orid, "runtime": f.Runtime})
+	}
+
+	if !query.Has("limit") && !query.Has("nextToken") {
+		writeJSON(w, http.StatusOK, functions)
+		return
+	}
+
+	start, limit := 0, len(functions)
+	if token := query.Get("nextToken"); token != "" {
+		var err error
+		if start, err = strconv.Atoi(token); err != nil || start < 0 || start > len(functions) {
+			writeError(w, http.StatusBadRequest, "invalid nextToken")
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	end := start + limit
+	nextToken := ""
+	if end < len(functions) {
+		nextToken = strconv.Itoa(end)
+	} else {
+		end = len(functions)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"functions": functions[start:end], "nextToken": nextToken})
+}
+
+func (s *Server) inspectFunction(w http.ResponseWriter, r *http.Request, functionOrid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.funcs[functionOrid]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"orid":           f.Orid,
+		"name":           f.Name,
+		"version":        strconv.Itoa(f.Version),
+		"runtime":        f.Runtime,
+		"entryPoint":     f.EntryPoint,
+		"created":        formatTime(f.Created),
+		"lastUpdate":     formatTime(f.LastUpdate),
+		"lastInvoke":     formatTime(f.LastInvoke),
+		"status":         f.BuildStatus,
+		"buildLogs":      f.BuildLogs,
+		"context":        f.Context,
+		"sourceHash":     f.SourceHash,
+		"environment":    f.Environment,
+		"memorySize":     f.MemorySize,
+		"timeoutSeconds": f.TimeoutSeconds,
+		"tags":           f.Tags,
+	})
+}
+
+func (s *Server) uploadFunctionCode(w http.ResponseWriter, r *http.Request, functionOrid string) {
+	file, _, err := r.FormFile("sourceArchive")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "sourceArchive is required")
+		return
+	}
+	defer file.Close()
+
+	source, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "could not read sourceArchive")
+		return
+	}
+	if r.FormValue("runtime") == "" || r.FormValue("entryPoint") == "" {
+		writeError(w, http.StatusBadRequest, "runtime and entryPoint are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.funcs[functionOrid]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f.Version++
+	f.Runtime = r.FormValue("runtime")
+	f.EntryPoint = r.FormValue("entryPoint")
+	f.Context = r.FormValue("context")
+	f.Source = source
+	f.SourceHash = r.FormValue("sourceHash")
+	f.LastUpdate = time.Now()
+	f.BuildLogs = ""
+	f.publishVersion()
+	f.BuildStatus = "buildComplete"
+	if s.asyncBuilds {
+		f.BuildStatus = "building"
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]string{"orid": f.Orid, "status": f.BuildStatus})
+}
+
+func (s *Server) invokeFunction(w http.ResponseWriter, r *http.Request, functionOrid string) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "could not read request")
+		return
+	}
+
+	s.mu.Lock()
+	f, ok := s.funcs[functionOrid]
+	if !ok {
+		s.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	if f.Version == 0 || f.BuildStatus != "buildComplete" {
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "function is not ready to invoke")
+		return
+	}
+	function := *f
+	if qualifier := r.URL.Query().Get("qualifier"); qualifier != "" {
+		version, ok := f.resolve(qualifier)
+		if !ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "unknown version or alias")
+			return
+		}
+		function.Version = version.Version
+		function.Runtime = version.Runtime
+		function.EntryPoint = version.EntryPoint
+		function.Source = version.Source
+		function.SourceHash = version.SourceHash
+	}
+	f.LastInvoke = time.Now()
+	handler := s.invokeHandler
+	logs := s.invocationLogs
+	s.mu.Unlock()
+
+	if r.URL.Query().Get("async") == "true" {
+		s.startInvocation(w, function, body, handler, logs)
+		return
+	}
+
+	inv := runInvocation(newID(), function, body, handler, logs)
+	s.mu.Lock()
+	s.appendLogs(function.Orid, inv.id, inv.logs)
+	s.mu.Unlock()
+
+	w.Header().Set("X-Mds-Invocation-Id", inv.id)
+	w.Header().Set("X-Mds-Function-Version", strconv.Itoa(inv.version))
+	w.Header().Set("X-Mds-Duration-Ms", strconv.FormatInt(inv.duration.Milliseconds(), 10))
+	if inv.logs != "" {
+		w.Header().Set("X-Mds-Logs", base64.StdEncoding.EncodeToString([]byte(inv.logs)))
+	}
+
+	if inv.status == invocationFailed {
+		w.Header().Set("X-Mds-Function-Error", "Unhandled")
+		writeError(w, http.StatusBadRequest, inv.err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(inv.result)
+}
+
+func (s *Server) updateFunctionConfiguration(w http.ResponseWriter, r *http.Request, functionOrid string) {
+	body := struct {
+		Runtime        *string           `json:"runtime"`
+		EntryPoint     *string           `json:"entryPoint"`
+		Context        *string           `json:"context"`
+		Environment    map[string]string `json:"environment"`
+		MemorySize     *int              `json:"memorySize"`
+		TimeoutSeconds *int              `json:"timeoutSeconds"`
+		Tags           map[string]string `json:"tags"`
+	}{}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "could not read request")
+		return
+	}
+	if (body.MemorySize != nil && *body.MemorySize <= 0) || (body.TimeoutSeconds != nil && *body.TimeoutSeconds <= 0) {
+		writeError(w, http.StatusBadRequest, "memorySize and timeoutSeconds must be positive")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.funcs[functionOrid]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if body.Runtime != nil {
+		f.Runtime = *body.Runtime
+	}
+	if body.EntryPoint != nil {
+		f.EntryPoint = *body.EntryPoint
+	}
+	if body.Context != nil {
+		f.Context = *body.Context
+	}
+	if body.Environment != nil {
+		f.Environment = body.Environment
+	}
+	if body.MemorySize != nil {
+		f.MemorySize = *body.MemorySize
+	}
+	if body.TimeoutSeconds != nil {
+		f.TimeoutSeconds = *body.TimeoutSeconds
+	}
+	if body.Tags != nil {
+		f.Tags = body.Tags
+	}
+	f.LastUpdate = time.Now()
+
+	writeJSON(w, http.StatusOK, map[string]string{"orid": f.Orid})
+}
+
+// sortedFunctions Functions belonging to the account ordered by name. Callers must hold the lock.
+func (s *Server) sortedFunctions(accountID string) []*Function {
+	functions := make([]*Function, 0)
+	for _, f := range s.funcs {
+		if o, err := orid.Parse(f.Orid); err == nil && o.AccountID == accountID {
+			functions = append(functions, f)
+		}
+	}
+	sort.Slice(functions, func(i, j int) bool {
+		return functions[i].Name < functions[j].Name
+	})
+	return functions
+}
+
+// Functions Returns copies of the functions held by the server
+func (s *Server) Functions() []Function {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	functions := make([]Function, 0, len(s.funcs))
+	for _, f := range s.funcs {
+		functions = append(functions, *f)
+	}
+	return functions
+}
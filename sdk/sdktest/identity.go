@@ -0,0 +1,135 @@
+package sdktest
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) serveIdentity(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/v1/register":
+		s.register(w, r)
+	case r.Method == "POST" && r.URL.Path == "/v1/authenticate":
+		s.authenticate(w, r)
+	case r.Method == "POST" && r.URL.Path == "/v1/updateUser":
+		s.authenticated(s.updateUser).ServeHTTP(w, r)
+	case r.Method == "POST" && r.URL.Path == "/v1/impersonate":
+		s.authenticated(s.impersonate).ServeHTTP(w, r)
+	case r.Method == "GET" && r.URL.Path == "/v1/publicSignature":
+		writeJSON(w, http.StatusOK, map[string]string{"signature": string(s.signingKey)})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) register(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		UserID       string `json:"userId"`
+		Email        string `json:"email"`
+		Password     string `json:"password"`
+		FriendlyName string `json:"friendlyName"`
+		AccountName  string `json:"accountName"`
+	}{}
+	if err := readJSON(r, &body); err != nil || body.UserID == "" || body.Password == "" {
+		writeError(w, http.StatusBadRequest, "userId and password are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := ""
+	for n := 1001; accountID == "" || s.accountExists(accountID); n++ {
+		accountID = strconv.Itoa(n)
+	}
+	s.users[accountID+"|"+body.UserID] = &user{
+		AccountID:    accountID,
+		AccountName:  body.AccountName,
+		UserID:       body.UserID,
+		Email:        body.Email,
+		Password:     body.Password,
+		FriendlyName: body.FriendlyName,
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "Success", "accountId": accountID})
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		AccountID string `json:"accountId"`
+		UserID    string `json:"userId"`
+		Password  string `json:"password"`
+	}{}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "could not decode request")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[body.AccountID+"|"+body.UserID]
+	if !ok || u.Password != body.Password {
+		writeError(w, http.StatusBadRequest, "Could not find account, user or passwords did not match")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"token": s.issueToken(u.AccountID, u.UserID)})
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request, accountID string) {
+	body := struct {
+		Email        string `json:"email"`
+		OldPassword  string `json:"oldPassword"`
+		NewPassword  string `json:"newPassword"`
+		FriendlyName string `json:"friendlyName"`
+	}{}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "could not decode request")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess := s.tokens[r.Header.Get("Token")]
+	u := s.users[accountID+"|"+sess.UserID]
+	if body.NewPassword != "" {
+		if body.OldPassword != u.Password {
+			writeError(w, http.StatusBadRequest, "old password did not match")
+			return
+		}
+		u.Password = body.NewPassword
+	}
+	if body.Email != "" {
+		u.Email = body.Email
+	}
+	if body.FriendlyName != "" {
+		u.FriendlyName = body.FriendlyName
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) impersonate(w http.ResponseWriter, r *http.Request, accountID string) {
+	body := struct {
+		AccountID string `json:"accountId"`
+	}{}
+	if err := readJSON(r, &body); err != nil || body.AccountID == "" {
+		writeError(w, http.StatusBadRequest, "accountId is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess := s.tokens[r.Header.Get("Token")]
+	writeJSON(w, http.StatusOK, map[string]string{"token": s.issueToken(body.AccountID, sess.UserID)})
+}
+
+// accountExists Reports whether any user belongs to the account. Callers must hold the lock.
+func (s *Server) accountExists(accountID string) bool {
+	for _, u := range s.users {
+		if u.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
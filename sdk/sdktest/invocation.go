@@ -0,0 +1,122 @@
+package sdktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// Statuses of an asynchronous invocation
+const (
+	invocationPending   = "pending"
+	invocationSucceeded = "succeeded"
+	invocationFailed    = "failed"
+)
+
+// invocation Outcome of invoking a function
+type invocation struct {
+	id           string
+	functionOrid string
+	version      int
+	status       string
+	result       []byte
+	err          string
+	logs         string
+	duration     time.Duration
+}
+
+// SetInvocationDelay Delays completing asynchronous invocations, leaving them pending in the meantime
+func (s *Server) SetInvocationDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invocationDelay = delay
+}
+
+// runInvocation Invokes the function, echoing the body when no handler is set
+func runInvocation(id string, function Function, body []byte, handler InvokeHandler, logs string) *invocation {
+	inv := &invocation{id: id, functionOrid: function.Orid, version: function.Version, logs: logs}
+	started := time.Now()
+
+	result, err := body, error(nil)
+	if handler != nil {
+		result, err = handler(function, body)
+	}
+
+	inv.duration = time.Since(started)
+	if err != nil {
+		inv.status = invocationFailed
+		inv.err = err.Error()
+	} else {
+		inv.status = invocationSucceeded
+		inv.result = result
+	}
+	return inv
+}
+
+// startInvocation Records a pending invocation and completes it in the background
+func (s *Server) startInvocation(w http.ResponseWriter, function Function, body []byte, handler InvokeHandler, logs string) {
+	id := newID()
+
+	s.mu.Lock()
+	s.invocations[id] = &invocation{id: id, functionOrid: function.Orid, version: function.Version, status: invocationPending}
+	delay := s.invocationDelay
+	s.mu.Unlock()
+
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-s.closed:
+			return
+		}
+
+		inv := runInvocation(id, function, body, handler, logs)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.invocations[id] = inv
+		s.appendLogs(function.Orid, id, inv.logs)
+	}()
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"invocationId": id})
+}
+
+func (s *Server) getInvocation(w http.ResponseWriter, r *http.Request, accountID string, invocationID string) {
+	s.mu.Lock()
+	inv, ok := s.invocations[invocationID]
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if o, err := orid.Parse(inv.functionOrid); err != nil || o.AccountID != accountID {
+		http.NotFound(w, r)
+		return
+	}
+
+	body := map[string]interface{}{
+		"invocationId": inv.id,
+		"orid":         inv.functionOrid,
+		"status":       inv.status,
+		"version":      strconv.Itoa(inv.version),
+	}
+	if inv.status != invocationPending {
+		body["durationMs"] = inv.duration.Milliseconds()
+		body["logs"] = inv.logs
+	}
+	if inv.status == invocationSucceeded && json.Valid(inv.result) {
+		body["result"] = json.RawMessage(inv.result)
+	}
+	if inv.status == invocationFailed {
+		body["error"] = inv.err
+	}
+	writeJSON(w, http.StatusOK, body)
+}
@@ -0,0 +1,102 @@
+package sdktest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLogLimit Log entries returned when the request does not give a limit
+const DefaultLogLimit = 100
+
+// LogEntry A line written by a function
+type LogEntry struct {
+	ID           string
+	InvocationID string
+	Timestamp    time.Time
+	Message      string
+}
+
+// AddFunctionLog Appends a log line to a function as though it had been written by the function
+func (s *Server) AddFunctionLog(functionOrid string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appendLogs(functionOrid, "", message)
+}
+
+// appendLogs Records each line of the logs. Callers must hold the lock.
+func (s *Server) appendLogs(functionOrid string, invocationID string, logs string) {
+	now := time.Now()
+	for _, line := range strings.Split(strings.TrimRight(logs, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		s.logs[functionOrid] = append(s.logs[functionOrid], LogEntry{
+			ID:           newID(),
+			InvocationID: invocationID,
+			Timestamp:    now,
+			Message:      line,
+		})
+	}
+}
+
+// listFunctionLogs Returns the oldest log entries written at or after the since query parameter, skipping the first
+// offset of them
+func (s *Server) listFunctionLogs(w http.ResponseWriter, r *http.Request, functionOrid string) {
+	query := r.URL.Query()
+
+	var since time.Time
+	if value := query.Get("since"); value != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid since")
+			return
+		}
+	}
+	offset := 0
+	if value := query.Get("offset"); value != "" {
+		var err error
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+	}
+	limit := DefaultLogLimit
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.funcs[functionOrid]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	entries := make([]map[string]string, 0)
+	for _, entry := range s.logs[functionOrid] {
+		if entry.Timestamp.Before(since) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if len(entries) == limit {
+			break
+		}
+		entries = append(entries, map[string]string{
+			"id":           entry.ID,
+			"invocationId": entry.InvocationID,
+			"timestamp":    entry.Timestamp.UTC().Format(time.RFC3339Nano),
+			"message":      entry.Message,
+		})
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
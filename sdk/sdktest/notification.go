@@ -0,0 +1,41 @@
+package sdktest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+func (s *Server) serveNotifications(w http.ResponseWriter, r *http.Request, accountID string) {
+	value, ok := splitPath(r.URL.Path, "/v1/emit/")
+	if !ok || r.Method != "POST" {
+		http.NotFound(w, r)
+		return
+	}
+	if _, ok := resourceOrid(value, accountID, orid.ServiceNotification); !ok {
+		writeError(w, http.StatusBadRequest, "invalid topic orid")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil || !json.Valid(body) {
+		writeError(w, http.StatusBadRequest, "message must be JSON")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages[value] = append(s.messages[value], json.RawMessage(body))
+	w.WriteHeader(http.StatusOK)
+}
+
+// Messages Returns the messages emitted to a notification topic in the order received
+func (s *Server) Messages(topicOrid string) []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]json.RawMessage(nil), s.messages[topicOrid]...)
+}
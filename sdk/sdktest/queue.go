@@ -0,0 +1,112 @@
+package sdktest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// Queue A queue held by the server
+type Queue struct {
+	Orid     string
+	Name     string
+	Resource string
+	Dlq      string
+}
+
+func (s *Server) serveQueues(w http.ResponseWriter, r *http.Request, accountID string) {
+	if r.Method == "POST" && r.URL.Path == "/v1/queue" {
+		s.createQueue(w, r, accountID)
+		return
+	}
+
+	rest, ok := splitPath(r.URL.Path, "/v1/queue/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	details := strings.HasSuffix(rest, "/details")
+	rest = strings.TrimSuffix(rest, "/details")
+	if _, ok := resourceOrid(rest, accountID, orid.ServiceQueue); !ok {
+		writeError(w, http.StatusBadRequest, "invalid queue orid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, ok := s.queues[rest]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case r.Method == "GET" && details:
+		writeJSON(w, http.StatusOK, map[string]string{"resource": queue.Resource, "dlq": queue.Dlq})
+	case r.Method == "POST" && !details:
+		body := struct {
+			Resource *string `json:"resource"`
+			Dlq      *string `json:"dlq"`
+		}{}
+		if err := readJSON(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "could not decode request")
+			return
+		}
+		if body.Resource != nil {
+			queue.Resource = *body.Resource
+		}
+		if body.Dlq != nil {
+			queue.Dlq = *body.Dlq
+		}
+		w.WriteHeader(http.StatusOK)
+	case r.Method == "DELETE" && !details:
+		delete(s.queues, rest)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) createQueue(w http.ResponseWriter, r *http.Request, accountID string) {
+	body := struct {
+		Name     string `json:"name"`
+		Resource string `json:"resource"`
+		Dlq      string `json:"dlq"`
+	}{}
+	if err := readJSON(r, &body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queueOrid := newOrid(accountID, orid.ServiceQueue, body.Name)
+	if _, ok := s.queues[queueOrid]; ok {
+		writeJSON(w, http.StatusOK, map[string]string{"name": body.Name, "orid": queueOrid})
+		return
+	}
+
+	s.queues[queueOrid] = &Queue{
+		Orid:     queueOrid,
+		Name:     body.Name,
+		Resource: body.Resource,
+		Dlq:      body.Dlq,
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"name": body.Name, "orid": queueOrid})
+}
+
+// Queues Returns copies of the queues held by the server
+func (s *Server) Queues() []Queue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queues := make([]Queue, 0, len(s.queues))
+	for _, queue := range s.queues {
+		queues = append(queues, *queue)
+	}
+	return queues
+}
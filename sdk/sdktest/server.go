@@ -0,0 +1,229 @@
+// Package sdktest Provides an in-process stand-in for an MDS Cloud deployment
+//
+// The server keeps all state in memory and serves the identity, queue, file, state machine, serverless
+// function and notification service endpoints used by the SDK. Pass URLs to sdk.NewSdk to exercise the
+// clients end-to-end without a real deployment:
+//
+//	srv := sdktest.NewServer()
+//	defer srv.Close()
+//	s := sdk.NewSdk(sdktest.DefaultAccountID, sdktest.DefaultUserID, sdktest.DefaultPassword, false, false, srv.URLs())
+package sdktest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// Credentials of the account registered when the server starts
+const (
+	DefaultAccountID = "1001"
+	DefaultUserID    = "testUser"
+	DefaultPassword  = "password"
+)
+
+// TokenLifetime Lifetime of the tokens issued by the server
+const TokenLifetime = time.Hour
+
+// Server In-memory MDS Cloud deployment backed by an httptest.Server
+type Server struct {
+	srv        *httptest.Server
+	mu         sync.Mutex
+	signingKey []byte
+
+	users    map[string]*user
+	tokens   map[string]*session
+	queues   map[string]*Queue
+	files    map[string]*container
+	machines map[string]*StateMachine
+	funcs    map[string]*Function
+	messages map[string][]json.RawMessage
+	logs     map[string][]LogEntry
+
+	invokeHandler   InvokeHandler
+	invocationLogs  string
+	invocationDelay time.Duration
+	invocations     map[string]*invocation
+	asyncBuilds     bool
+
+	// closed is closed by Close to stop background work, which background tracks
+	closed     chan struct{}
+	closeOnce  sync.Once
+	background sync.WaitGroup
+}
+
+type user struct {
+	AccountID    string
+	AccountName  string
+	UserID       string
+	Email        string
+	Password     string
+	FriendlyName string
+}
+
+type session struct {
+	AccountID string
+	UserID    string
+}
+
+// NewServer Starts a new server with the default account registered
+func NewServer() *Server {
+	s := &Server{
+		signingKey: []byte("sdktest-signing-key"),
+		users:      make(map[string]*user),
+		tokens:     make(map[string]*session),
+		queues:     make(map[string]*Queue),
+		files:      make(map[string]*container),
+		machines:   make(map[string]*StateMachine),
+		funcs:      make(map[string]*Function),
+		messages:   make(map[string][]json.RawMessage),
+		logs:       make(map[string][]LogEntry),
+
+		invocations: make(map[string]*invocation),
+		closed:      make(chan struct{}),
+	}
+	s.AddAccount(DefaultAccountID, DefaultUserID, DefaultPassword)
+
+	mux := http.NewServeMux()
+	mux.Handle("/identity/", http.StripPrefix("/identity", http.HandlerFunc(s.serveIdentity)))
+	mux.Handle("/qs/", http.StripPrefix("/qs", s.authenticated(s.serveQueues)))
+	mux.Handle("/fs/", http.StripPrefix("/fs", s.authenticated(s.serveFiles)))
+	mux.Handle("/sm/", http.StripPrefix("/sm", s.authenticated(s.serveStateMachines)))
+	mux.Handle("/sf/", http.StripPrefix("/sf", s.authenticated(s.serveFunctions)))
+	mux.Handle("/ns/", http.StripPrefix("/ns", s.authenticated(s.serveNotifications)))
+	s.srv = httptest.NewServer(mux)
+
+	return s
+}
+
+// Close Shuts down the server
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.srv.Close()
+		s.background.Wait()
+	})
+}
+
+// URL Base URL of the server
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// URLs Service URLs in the form expected by sdk.NewSdk
+func (s *Server) URLs() map[string]string {
+	return map[string]string{
+		"identityUrl": s.srv.URL + "/identity",
+		"qsUrl":       s.srv.URL + "/qs",
+		"fsUrl":       s.srv.URL + "/fs",
+		"smUrl":       s.srv.URL + "/sm",
+		"sfUrl":       s.srv.URL + "/sf",
+		"nsUrl":       s.srv.URL + "/ns",
+	}
+}
+
+// AddAccount Registers an account with a single user
+func (s *Server) AddAccount(accountID string, userID string, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[accountID+"|"+userID] = &user{
+		AccountID:   accountID,
+		AccountName: accountID,
+		UserID:      userID,
+		Password:    password,
+	}
+}
+
+// newID Generates a random version 4 UUID for a new resource
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func newOrid(accountID string, service string, resourceID string) string {
+	return orid.New(accountID, service, resourceID).String()
+}
+
+// issueToken Creates a signed JWT for the session. Callers must hold the lock.
+func (s *Server) issueToken(accountID string, userID string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"accountId": accountID,
+		"userId":    userID,
+		"iat":       time.Now().Unix(),
+		"exp":       time.Now().Add(TokenLifetime).Unix(),
+		"jti":       newID(),
+	})
+
+	unsigned := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(unsigned))
+	token := unsigned + "." + encode(mac.Sum(nil))
+
+	s.tokens[token] = &session{AccountID: accountID, UserID: userID}
+	return token
+}
+
+// authenticated Rejects requests without a token issued by the server
+func (s *Server) authenticated(next func(w http.ResponseWriter, r *http.Request, accountID string)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		sess, ok := s.tokens[r.Header.Get("Token")]
+		s.mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		next(w, r, sess.AccountID)
+	})
+}
+
+// resourceOrid Parses the orid at the end of a request path and checks it belongs to the account and service
+func resourceOrid(value string, accountID string, service string) (*orid.Orid, bool) {
+	o, err := orid.Parse(value)
+	if err != nil || o.AccountID != accountID || o.Service != service {
+		return nil, false
+	}
+	return o, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	io.WriteString(w, message)
+}
+
+func readJSON(r *http.Request, body interface{}) error {
+	return json.NewDecoder(r.Body).Decode(body)
+}
+
+// splitPath Removes the prefix from the request path, returning the remainder and whether the prefix matched
+func splitPath(path string, prefix string) (string, bool) {
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(path, prefix), true
+}
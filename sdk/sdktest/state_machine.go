@@ -0,0 +1,114 @@
+package sdktest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+// StateMachine A state machine held by the server
+type StateMachine struct {
+	Orid       string
+	Name       string
+	Definition json.RawMessage
+}
+
+func (s *Server) serveStateMachines(w http.ResponseWriter, r *http.Request, accountID string) {
+	if r.Method == "POST" && r.URL.Path == "/v1/machine" {
+		s.createStateMachine(w, r, accountID)
+		return
+	}
+
+	value, ok := splitPath(r.URL.Path, "/v1/machine/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if _, ok := resourceOrid(value, accountID, orid.ServiceStateMachine); !ok {
+		writeError(w, http.StatusBadRequest, "invalid state machine orid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	machine, ok := s.machines[value]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"orid":       machine.Orid,
+			"name":       machine.Name,
+			"definition": machine.Definition,
+		})
+	case "POST":
+		name, definition, ok := readDefinition(r)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid state machine definition")
+			return
+		}
+		machine.Name = name
+		machine.Definition = definition
+		writeJSON(w, http.StatusOK, map[string]string{"orid": machine.Orid})
+	case "DELETE":
+		delete(s.machines, value)
+		writeJSON(w, http.StatusOK, map[string]string{"orid": machine.Orid})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// readDefinition Reads a state machine definition, returning its name
+func readDefinition(r *http.Request) (string, json.RawMessage, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", nil, false
+	}
+
+	definition := struct {
+		Name     string                 `json:"Name"`
+		StartsAt string                 `json:"StartsAt"`
+		States   map[string]interface{} `json:"States"`
+	}{}
+	if err = json.Unmarshal(body, &definition); err != nil || definition.StartsAt == "" || len(definition.States) == 0 {
+		return "", nil, false
+	}
+	return definition.Name, json.RawMessage(body), true
+}
+
+func (s *Server) createStateMachine(w http.ResponseWriter, r *http.Request, accountID string) {
+	name, definition, ok := readDefinition(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid state machine definition")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	machineOrid := newOrid(accountID, orid.ServiceStateMachine, newID())
+	s.machines[machineOrid] = &StateMachine{
+		Orid:       machineOrid,
+		Name:       name,
+		Definition: definition,
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"orid": machineOrid})
+}
+
+// StateMachines Returns copies of the state machines held by the server
+func (s *Server) StateMachines() []StateMachine {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	machines := make([]StateMachine, 0, len(s.machines))
+	for _, machine := range s.machines {
+		machines = append(machines, *machine)
+	}
+	return machines
+}
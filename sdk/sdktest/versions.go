@@ -0,0 +1,155 @@
+package sdktest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FunctionVersion Code published by an upload of a function
+type FunctionVersion struct {
+	Version    int
+	Runtime    string
+	EntryPoint string
+	Source     []byte
+	SourceHash string
+	Created    time.Time
+}
+
+// publishVersion Records the function's current code as a new version. Callers must hold the lock.
+func (f *Function) publishVersion() {
+	f.Versions = append(f.Versions, FunctionVersion{
+		Version:    f.Version,
+		Runtime:    f.Runtime,
+		EntryPoint: f.EntryPoint,
+		Source:     f.Source,
+		SourceHash: f.SourceHash,
+		Created:    f.LastUpdate,
+	})
+}
+
+// resolve Finds the version a qualifier, either a version number or an alias, refers to. Callers must hold
+// the lock.
+func (f *Function) resolve(qualifier string) (*FunctionVersion, bool) {
+	version, err := strconv.Atoi(qualifier)
+	if err != nil {
+		if version, ok := f.Aliases[qualifier]; ok {
+			return f.resolve(strconv.Itoa(version))
+		}
+		return nil, false
+	}
+
+	for i := range f.Versions {
+		if f.Versions[i].Version == version {
+			return &f.Versions[i], true
+		}
+	}
+	return nil, false
+}
+
+// setAlias Points, or with a zero version removes, an alias. The map is replaced rather than modified so copies
+// returned by Functions are unaffected. Callers must hold the lock.
+func (f *Function) setAlias(name string, version int) {
+	aliases := make(map[string]int, len(f.Aliases)+1)
+	for k, v := range f.Aliases {
+		aliases[k] = v
+	}
+	if version == 0 {
+		delete(aliases, name)
+	} else {
+		aliases[name] = version
+	}
+	f.Aliases = aliases
+}
+
+func (s *Server) listFunctionVersions(w http.ResponseWriter, r *http.Request, functionOrid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.funcs[functionOrid]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	versions := make([]map[string]string, 0, len(f.Versions))
+	for _, v := range f.Versions {
+		versions = append(versions, map[string]string{
+			"version":    strconv.Itoa(v.Version),
+			"runtime":    v.Runtime,
+			"entryPoint": v.EntryPoint,
+			"sourceHash": v.SourceHash,
+			"created":    formatTime(v.Created),
+		})
+	}
+	writeJSON(w, http.StatusOK, versions)
+}
+
+// serveFunctionAliases Lists and creates aliases of a function, or updates and deletes the named alias
+func (s *Server) serveFunctionAliases(w http.ResponseWriter, r *http.Request, functionOrid string, name string) {
+	body := struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}{}
+	if r.Method == "POST" || r.Method == "PUT" {
+		if err := readJSON(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "could not read request")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.funcs[functionOrid]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case name == "" && r.Method == "GET":
+		aliases := make([]map[string]string, 0, len(f.Aliases))
+		for alias, version := range f.Aliases {
+			aliases = append(aliases, map[string]string{"name": alias, "version": strconv.Itoa(version)})
+		}
+		sort.Slice(aliases, func(i, j int) bool {
+			return aliases[i]["name"] < aliases[j]["name"]
+		})
+		writeJSON(w, http.StatusOK, aliases)
+	case name == "" && r.Method == "POST":
+		if _, exists := f.Aliases[body.Name]; exists {
+			writeError(w, http.StatusConflict, "alias already exists")
+			return
+		}
+		version, ok := f.resolve(body.Version)
+		if body.Name == "" || !ok {
+			writeError(w, http.StatusBadRequest, "name and an existing version are required")
+			return
+		}
+		f.setAlias(body.Name, version.Version)
+		writeJSON(w, http.StatusCreated, map[string]string{"name": body.Name, "version": strconv.Itoa(version.Version)})
+	case name != "" && r.Method == "PUT":
+		if _, exists := f.Aliases[name]; !exists {
+			http.NotFound(w, r)
+			return
+		}
+		version, ok := f.resolve(body.Version)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "an existing version is required")
+			return
+		}
+		f.setAlias(name, version.Version)
+		writeJSON(w, http.StatusOK, map[string]string{"name": name, "version": strconv.Itoa(version.Version)})
+	case name != "" && r.Method == "DELETE":
+		if _, exists := f.Aliases[name]; !exists {
+			http.NotFound(w, r)
+			return
+		}
+		f.setAlias(name, 0)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
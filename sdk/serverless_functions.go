@@ -2,61 +2,191 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
 )
 
 // ServerlessFunctionsClient Client to interact with MDS Cloud serverless functions
 type ServerlessFunctionsClient struct {
 	serviceURL  string
 	authManager *AuthManager
+	httpConfig  *httpConfig
+	ctx         context.Context
+}
+
+// WithContext Returns a copy of the client whose requests carry the context, i.e. for cancellation and tracing
+func (c *ServerlessFunctionsClient) WithContext(ctx context.Context) ServerlessFunctionsAPI {
+	return c.withContext(ctx)
+}
+
+func (c *ServerlessFunctionsClient) withContext(ctx context.Context) *ServerlessFunctionsClient {
+	client := *c
+	client.ctx = ctx
+	return &client
 }
 
 // ServerlessFunctionSummary Function summary details
 type ServerlessFunctionSummary struct {
-	Orid string
-	Name string
+	Orid    string
+	Name    string
+	Runtime string
 }
 
-// ServerlessFunctionDetails Data describing the serverless function
+// ServerlessFunctionDetails Data describing the serverless function. Timestamps the service does not report,
+// i.e. LastInvoke of a function never invoked, are zero.
+//
+// Status     - Build status, one of the FunctionStatus constants
+// SourceHash - Content hash of the source the function was last built from
+// MemorySize - Memory limit in megabytes
 type ServerlessFunctionDetails struct {
-	Orid       string
-	Name       string
-	Version    string
-	Runtime    string
-	EntryPoint string
-	Created    string
-	LastUpdate string
-	LastInvoke string
+	Orid        string
+	Name        string
+	Version     string
+	Runtime     string
+	EntryPoint  string
+	Created     time.Time
+	LastUpdate  time.Time
+	LastInvoke  time.Time
+	Context     string
+	Status      string
+	BuildLogs   string
+	SourceHash  string
+	Environment map[string]string
+	MemorySize  int
+	Timeout     time.Duration
+	Tags        map[string]string
+}
+
+// functionDetailsPayload Function details as returned by the serverless functions service
+type functionDetailsPayload struct {
+	Orid           string            `json:"orid"`
+	Name           string            `json:"name"`
+	Version        json.RawMessage   `json:"version"`
+	Runtime        string            `json:"runtime"`
+	EntryPoint     string            `json:"entryPoint"`
+	Created        string            `json:"created"`
+	LastUpdate     string            `json:"lastUpdate"`
+	LastInvoke     string            `json:"lastInvoke"`
+	Context        string            `json:"context"`
+	Status         string            `json:"status"`
+	BuildLogs      string            `json:"buildLogs"`
+	SourceHash     string            `json:"sourceHash"`
+	Environment    map[string]string `json:"environment"`
+	MemorySize     int               `json:"memorySize"`
+	TimeoutSeconds int               `json:"timeoutSeconds"`
+	Tags           map[string]string `json:"tags"`
+}
+
+// parseFunctionTime Parses an RFC 3339 timestamp, returning the zero time for empty values
+func parseFunctionTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// decodeFunctionVersion Reads a version given as either a JSON string or number, empty when missing or null
+func decodeFunctionVersion(raw json.RawMessage) string {
+	version := strings.Trim(string(raw), `"`)
+	if version == "null" {
+		return ""
+	}
+	return version
+}
+
+// toDetails Converts the payload, accepting the version as either a JSON string or number
+func (p *functionDetailsPayload) toDetails() (*ServerlessFunctionDetails, error) {
+	details := &ServerlessFunctionDetails{
+		Orid:        p.Orid,
+		Name:        p.Name,
+		Version:     decodeFunctionVersion(p.Version),
+		Runtime:     p.Runtime,
+		EntryPoint:  p.EntryPoint,
+		Context:     p.Context,
+		Status:      p.Status,
+		BuildLogs:   p.BuildLogs,
+		SourceHash:  p.SourceHash,
+		Environment: p.Environment,
+		MemorySize:  p.MemorySize,
+		Timeout:     time.Duration(p.TimeoutSeconds) * time.Second,
+		Tags:        p.Tags,
+	}
+	var err error
+	if details.Created, err = parseFunctionTime(p.Created); err != nil {
+		return nil, err
+	}
+	if details.LastUpdate, err = parseFunctionTime(p.LastUpdate); err != nil {
+		return nil, err
+	}
+	if details.LastInvoke, err = parseFunctionTime(p.LastInvoke); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// Build statuses reported by the serverless functions service
+const (
+	FunctionStatusBuilding      = "building"
+	FunctionStatusBuildComplete = "buildComplete"
+	FunctionStatusBuildFailed   = "buildFailed"
+)
+
+// FunctionBuildError Returned when the serverless functions service fails to build uploaded code
+type FunctionBuildError struct {
+	Orid string
+	Logs string
+}
+
+func (e *FunctionBuildError) Error() string {
+	if e.Logs == "" {
+		return "function failed to build"
+	}
+	return fmt.Sprintf("function failed to build: %s", e.Logs)
+}
+
+type createFunctionPayload struct {
+	Name string `json:"name"`
+}
+
+// buildCreateFunctionPayload Builds the JSON body sent to the serverless functions create endpoint
+func buildCreateFunctionPayload(name string) ([]byte, error) {
+	return json.Marshal(&createFunctionPayload{Name: name})
 }
 
 // CreateFunction Create a new serverless function
 func (c *ServerlessFunctionsClient) CreateFunction(name string) (*ServerlessFunctionSummary, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
 
-	token, err := c.authManager.GetAuthenticationToken(nil)
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
 	if err != nil {
 		return nil, errors.New("could not acquire authentication token")
 	}
 
-	body := []byte(fmt.Sprintf(`{"name":"%s"}`, name))
+	body, err := buildCreateFunctionPayload(name)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/create", c.serviceURL), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, errors.New("could not build request to create new function")
 	}
 
+	req = withOperation(c.ctx, req, "CreateFunction", "")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
 	if err != nil {
-		return nil, errors.New("could not execute request to create new function")
+		return nil, fmt.Errorf("could not execute request to create new function: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -65,7 +195,7 @@ func (c *ServerlessFunctionsClient) CreateFunction(name string) (*ServerlessFunc
 		function := make(map[string]interface{})
 		err = json.NewDecoder(r.Body).Decode(&function)
 		if err != nil {
-			fmt.Println(err)
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
 			return nil, errors.New("could not decode response from API of resource")
 		}
 		data := ServerlessFunctionSummary{
@@ -86,61 +216,40 @@ func (c *ServerlessFunctionsClient) CreateFunction(name string) (*ServerlessFunc
 
 // ListFunctions List the available functions
 func (c *ServerlessFunctionsClient) ListFunctions() (*[]ServerlessFunctionSummary, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
-
-	token, err := c.authManager.GetAuthenticationToken(nil)
-	if err != nil {
-		return nil, errors.New("could not acquire authentication token")
-	}
-
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/list", c.serviceURL), nil)
-	if err != nil {
-		return nil, errors.New("could not build request to fetch list of functions from API")
-	}
-
-	req.Header.Set("Token", token)
-	r, err := client.Do(req)
-	if err != nil {
-		return nil, errors.New("could not execute request to fetch list of functions from serverless functions API")
-	}
-	defer r.Body.Close()
-
-	apiFunctions := make([]map[string]interface{}, 0)
-	err = json.NewDecoder(r.Body).Decode(&apiFunctions)
-	if err != nil {
-		fmt.Println(err)
-		return nil, errors.New("could not decode response from API of resource")
-	}
-
 	functions := make([]ServerlessFunctionSummary, 0)
-	for _, f := range apiFunctions {
-		functions = append(functions, ServerlessFunctionSummary{
-			Orid: f["orid"].(string),
-			Name: f["name"].(string),
-		})
+	for function, err := range c.IterateFunctions(nil) {
+		if err != nil {
+			return nil, err
+		}
+		functions = append(functions, function)
 	}
 
 	return &functions, nil
 }
 
 // DeleteFunction .
-func (c *ServerlessFunctionsClient) DeleteFunction(orid string) error {
-	client := &http.Client{Timeout: API_TIMEOUT}
+func (c *ServerlessFunctionsClient) DeleteFunction(functionOrid string) error {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return err
+	}
 
-	token, err := c.authManager.GetAuthenticationToken(nil)
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
 	if err != nil {
 		return errors.New("could not acquire authentication token")
 	}
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v1/%s", c.serviceURL, orid), nil)
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v1/%s", c.serviceURL, functionOrid), nil)
 	if err != nil {
 		return errors.New("could not build request to delete function")
 	}
 
+	req = withOperation(c.ctx, req, "DeleteFunction", functionOrid)
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
 	if err != nil {
-		return errors.New("could not execute request to delete function")
+		return fmt.Errorf("could not execute request to delete function: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -154,139 +263,206 @@ func (c *ServerlessFunctionsClient) DeleteFunction(orid string) error {
 }
 
 // InvokeFunction .
-func (c *ServerlessFunctionsClient) InvokeFunction(orid string, body interface{}) (interface{}, error) {
-	client := &http.Client{Timeout: 30 * time.Minute}
+func (c *ServerlessFunctionsClient) InvokeFunction(functionOrid string, body interface{}) (interface{}, error) {
+	result, _, err := c.invoke(functionOrid, "", body)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetFunctionDetails Gets details for a function
+func (c *ServerlessFunctionsClient) GetFunctionDetails(functionOrid string) (*ServerlessFunctionDetails, error) {
+	if _, err := validateOrid(functionOrid, orid.ServiceServerlessFunctions); err != nil {
+		return nil, err
+	}
 
-	token, err := c.authManager.GetAuthenticationToken(nil)
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, API_TIMEOUT, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
 	if err != nil {
 		return nil, errors.New("could not acquire authentication token")
 	}
 
-	bodyBytes, _ := json.Marshal(body)
-	payload := bytes.NewReader(bodyBytes)
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/invoke/%s", c.serviceURL, orid), payload)
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/inspect/%s", c.serviceURL, functionOrid), nil)
 	if err != nil {
-		return nil, errors.New("could not build request to invoke function")
+		return nil, errors.New("could not build request to fetch function from API")
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req = withOperation(c.ctx, req, "GetFunctionDetails", functionOrid)
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
 	if err != nil {
-		return nil, errors.New("could not execute request to invoke function")
+		return nil, fmt.Errorf("could not execute request to fetch function from serverless functions API: %w", err)
 	}
 	defer r.Body.Close()
 
 	switch r.StatusCode {
 	case 200:
-		body, _ := io.ReadAll(r.Body)
-		return body, nil
-	case 400:
-		body, _ := io.ReadAll(r.Body)
-		return nil, errors.New(string(body))
+		payload := functionDetailsPayload{}
+		err = json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
+			return nil, errors.New("could not decode response from API of resource")
+		}
+
+		details, err := payload.toDetails()
+		if err != nil {
+			c.httpConfig.debug("could not parse serverless function timestamps", "error", err)
+			return nil, fmt.Errorf("could not decode response from API of resource: %w", err)
+		}
+		return details, nil
+	case 404:
+		return nil, fmt.Errorf("function %s: %w", functionOrid, ErrNotFound)
 	default:
 		body, _ := io.ReadAll(r.Body)
 		return nil, fmt.Errorf("did not understand response from API: %d, %s", r.StatusCode, string(body))
 	}
 }
 
-// GetFunctionDetails Gets details for a function
-func (c *ServerlessFunctionsClient) GetFunctionDetails(orid string) (*ServerlessFunctionDetails, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+// WaitForFunctionReady Polls the function with backoff until its code has built and it can be invoked
+//
+// Returns a *FunctionBuildError carrying the build logs when the build fails, or the error of the client's
+// context, see WithContext, when it ends first. Functions whose details do not report a build status are
+// treated as ready.
+func (c *ServerlessFunctionsClient) WaitForFunctionReady(functionOrid string, opts *WaitOptions) (*ServerlessFunctionDetails, error) {
+	var details *ServerlessFunctionDetails
+	err := poll(c.ctx, opts, func() (bool, error) {
+		var err error
+		details, err = c.GetFunctionDetails(functionOrid)
+		if err != nil {
+			return false, err
+		}
 
-	token, err := c.authManager.GetAuthenticationToken(nil)
+		switch details.Status {
+		case FunctionStatusBuildFailed:
+			return false, &FunctionBuildError{Orid: functionOrid, Logs: details.BuildLogs}
+		case FunctionStatusBuildComplete, "":
+			return true, nil
+		default:
+			return false, nil
+		}
+	})
 	if err != nil {
-		return nil, errors.New("could not acquire authentication token")
+		return nil, err
 	}
+	return details, nil
+}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/inspect/%s", c.serviceURL, orid), nil)
-	if err != nil {
-		return nil, errors.New("could not build request to fetch function from API")
-	}
+// UpdateFunctionCode .
+//
+// SourcePathOrFile - Path to a source archive, or to a directory that is zipped before upload
+// IgnorePatterns   - Optional gitignore style patterns excluding files when SourcePathOrFile is a directory.
+// Patterns from the .gitignore and .mdsignore files at the root of the directory are always applied.
+// SkipUnchanged    - Skip the upload when the function was already built from identical source and settings.
+// The service must store the sourceHash form field sent with the code and return it in the function details,
+// otherwise every upload still happens after an extra details request.
+// Progress         - Optional callback reporting the bytes of source streamed to the API
+type UpdateFunctionCodeArgs struct {
+	Orid             string
+	Runtime          string
+	EntryPoint       string
+	SourcePathOrFile string
+	Context          string
+	IgnorePatterns   []string
+	SkipUnchanged    bool
+	Progress         UploadProgressFunc
+}
 
-	req.Header.Set("Token", token)
-	r, err := client.Do(req)
+// writeFunctionCodeForm Streams the multipart form uploading a function's code
+func writeFunctionCodeForm(writer *multipart.Writer, source *functionSource, data *UpdateFunctionCodeArgs, sourceHash string) error {
+	fileWriter, err := writer.CreateFormFile("sourceArchive", source.name)
 	if err != nil {
-		return nil, errors.New("could not execute request to fetch function from serverless functions API")
+		return err
 	}
-	defer r.Body.Close()
-
-	function := make(map[string]interface{})
-	err = json.NewDecoder(r.Body).Decode(&function)
-	if err != nil {
-		fmt.Println(err)
-		return nil, errors.New("could not decode response from API of resource")
+	if err = source.writeTo(fileWriter, data.Progress); err != nil {
+		return err
 	}
 
-	safeToString := func(data interface{}) string {
-		if data == nil {
-			return ""
+	fields := [][2]string{{"runtime", data.Runtime}, {"entryPoint", data.EntryPoint}, {"sourceHash", sourceHash}}
+	if data.Context != "" {
+		fields = append(fields, [2]string{"context", data.Context})
+	}
+	for _, field := range fields {
+		if err = writer.WriteField(field[0], field[1]); err != nil {
+			return err
 		}
-		return data.(string)
 	}
 
-	data := &ServerlessFunctionDetails{
-		Orid:       safeToString(function["orid"]),
-		Name:       safeToString(function["name"]),
-		Version:    safeToString(function["version"]),
-		Runtime:    safeToString(function["runtime"]),
-		EntryPoint: safeToString(function["entryPoint"]),
-		Created:    safeToString(function["created"]),
-		LastUpdate: safeToString(function["lastUpdate"]),
-		LastInvoke: safeToString(function["lastInvoke"]),
-	}
-	return data, nil
+	return writer.Close()
 }
 
-// UpdateFunctionCode .
-type UpdateFunctionCodeArgs struct {
-	Orid             string
-	Runtime          string
-	EntryPoint       string
-	SourcePathOrFile string
-	Context          string
+// functionCodeUnchanged Reports whether the function was successfully built from the same source and settings
+func functionCodeUnchanged(details *ServerlessFunctionDetails, data *UpdateFunctionCodeArgs, sourceHash string) bool {
+	return details.SourceHash == sourceHash &&
+		details.Status == FunctionStatusBuildComplete &&
+		details.Runtime == data.Runtime &&
+		details.EntryPoint == data.EntryPoint &&
+		details.Context == data.Context
 }
 
 func (c *ServerlessFunctionsClient) UpdateFunctionCode(data *UpdateFunctionCodeArgs) error {
-	client := &http.Client{Timeout: 30 * time.Minute}
+	if _, err := validateOrid(data.Orid, orid.ServiceServerlessFunctions); err != nil {
+		return err
+	}
 
-	token, err := c.authManager.GetAuthenticationToken(nil)
+	source, err := openFunctionSource(data.SourcePathOrFile, data.IgnorePatterns)
 	if err != nil {
-		return errors.New("could not acquire authentication token")
+		return err
 	}
+	defer source.Close()
 
-	payload := &bytes.Buffer{}
-	writer := multipart.NewWriter(payload)
-	file, err := os.Open(data.SourcePathOrFile)
-	defer file.Close()
-
-	fileWriter, err := writer.CreateFormFile("sourceArchive", filepath.Base(data.SourcePathOrFile))
-	_, err = io.Copy(fileWriter, file)
+	sourceHash, err := source.hash()
 	if err != nil {
 		return err
 	}
-	_ = writer.WriteField("runtime", data.Runtime)
-	_ = writer.WriteField("entryPoint", data.EntryPoint)
 
-	if data.Context != "" {
-		_ = writer.WriteField("context", data.Context)
+	if data.SkipUnchanged {
+		details, err := c.GetFunctionDetails(data.Orid)
+		if err == nil && functionCodeUnchanged(details, data, sourceHash) {
+			c.httpConfig.debug("skipping upload of unchanged function code", "orid", data.Orid, "sourceHash", sourceHash)
+			return nil
+		}
 	}
 
-	err = writer.Close()
+	client := c.httpConfig.newClient(orid.ServiceServerlessFunctions, 30*time.Minute, false)
+
+	token, err := c.authManager.GetAuthenticationTokenContext(c.ctx, nil)
 	if err != nil {
-		return err
+		return errors.New("could not acquire authentication token")
 	}
 
+	payload, pipeWriter := io.Pipe()
+	writer := multipart.NewWriter(pipeWriter)
+
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/uploadCode/%s", c.serviceURL, data.Orid), payload)
 	if err != nil {
 		return errors.New("could not build request to create new function")
 	}
 
+	uploadErr := make(chan error, 1)
+	go func() {
+		err := writeFunctionCodeForm(writer, source, data, sourceHash)
+		pipeWriter.CloseWithError(err)
+		uploadErr <- err
+	}()
+
+	req = withOperation(c.ctx, req, "UpdateFunctionCode", data.Orid)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
+
+	// Unblock the writer when the request ended before the body was consumed, then surface source read errors
+	// in preference to the transport error they caused.
+	payload.Close()
+	if writeErr := <-uploadErr; writeErr != nil && !errors.Is(writeErr, io.ErrClosedPipe) {
+		if err == nil {
+			r.Body.Close()
+		}
+		return fmt.Errorf("could not read function source: %w", writeErr)
+	}
 	if err != nil {
-		return errors.New("could not execute request to create new function")
+		return fmt.Errorf("could not execute request to create new function: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -295,13 +471,14 @@ func (c *ServerlessFunctionsClient) UpdateFunctionCode(data *UpdateFunctionCodeA
 		result := make(map[string]interface{})
 		err = json.NewDecoder(r.Body).Decode(&result)
 		if err != nil {
-			fmt.Println(err)
+			c.httpConfig.debug("could not decode serverless functions response", "error", err)
 			return errors.New("could not decode response from API of resource")
 		}
 
-		buildStatus := result["status"].(string)
-		if buildStatus == "buildFailed" {
-			return errors.New("function failed to build")
+		buildStatus, _ := result["status"].(string)
+		if buildStatus == FunctionStatusBuildFailed {
+			buildLogs, _ := result["buildLogs"].(string)
+			return &FunctionBuildError{Orid: data.Orid, Logs: buildLogs}
 		}
 
 		return nil
@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func createTestFunction(t *testing.T, client *ServerlessFunctionsClient) string {
+	summary, err := client.CreateFunction("test")
+	if err != nil {
+		t.Fatalf("Unexpected error creating function: %s", err)
+	}
+	return summary.Orid
+}
+
+func uploadTestFunction(t *testing.T, client *ServerlessFunctionsClient, functionOrid string) {
+	source := filepath.Join(t.TempDir(), "source.zip")
+	os.WriteFile(source, []byte("archive"), 0600)
+
+	err := client.UpdateFunctionCode(&UpdateFunctionCodeArgs{
+		Orid:             functionOrid,
+		Runtime:          "node",
+		EntryPoint:       "src/one:main",
+		SourcePathOrFile: source,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error uploading code: %s", err)
+	}
+}
+
+var fastWait = &WaitOptions{InitialInterval: 5 * time.Millisecond, MaxInterval: 20 * time.Millisecond}
+
+func TestWaitForFunctionReady(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetAsyncBuilds(true)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		srv.SetFunctionBuild(functionOrid, FunctionStatusBuildComplete, "")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	details, err := client.WithContext(ctx).WaitForFunctionReady(functionOrid, fastWait)
+	if err != nil {
+		t.Fatalf("Unexpected error waiting: %s", err)
+	}
+	assertString(t, details.Status, FunctionStatusBuildComplete, "Status incorrect")
+}
+
+func TestWaitForFunctionReadyReturnsBuildLogs(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetAsyncBuilds(true)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+	srv.SetFunctionBuild(functionOrid, FunctionStatusBuildFailed, "npm ERR! missing script")
+
+	_, err := client.WaitForFunctionReady(functionOrid, fastWait)
+	var buildErr *FunctionBuildError
+	if !errors.As(err, &buildErr) {
+		t.Fatalf("Expected build error, got: %v", err)
+	}
+	assertString(t, buildErr.Logs, "npm ERR! missing script", "Build logs incorrect")
+}
+
+func TestWaitForFunctionReadyHonorsContext(t *testing.T) {
+	sdk, srv := newTestSdk(t)
+	srv.SetAsyncBuilds(true)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := client.WithContext(ctx).WaitForFunctionReady(functionOrid, fastWait)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got: %v", err)
+	}
+}
+
+func TestGetFunctionDetails(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	uploadTestFunction(t, client, functionOrid)
+	err := client.UpdateFunctionConfiguration(&UpdateFunctionConfigurationArgs{
+		Orid:        functionOrid,
+		Context:     "ctx",
+		Environment: map[string]string{"LEVEL": "debug"},
+		Timeout:     time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error configuring function: %s", err)
+	}
+
+	details, err := client.GetFunctionDetails(functionOrid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, details.Version, "1", "Version incorrect")
+	assertString(t, details.Context, "ctx", "Context incorrect")
+	assertString(t, details.Status, FunctionStatusBuildComplete, "Status incorrect")
+	assertString(t, details.Environment["LEVEL"], "debug", "Environment incorrect")
+	if details.Timeout != time.Minute {
+		t.Errorf("Timeout incorrect, got %s", details.Timeout)
+	}
+	if details.SourceHash == "" {
+		t.Error("Expected source hash")
+	}
+	if details.Created.IsZero() || details.LastUpdate.Before(details.Created.Add(-time.Second)) {
+		t.Errorf("Unexpected timestamps created %s, last update %s", details.Created, details.LastUpdate)
+	}
+	if !details.LastInvoke.IsZero() {
+		t.Errorf("Expected zero last invoke, got %s", details.LastInvoke)
+	}
+}
+
+func TestGetFunctionDetailsNotFound(t *testing.T) {
+	sdk, _ := newTestSdk(t)
+	client := sdk.GetServerlessFunctionsClient()
+	functionOrid := createTestFunction(t, client)
+	if err := client.DeleteFunction(functionOrid); err != nil {
+		t.Fatalf("Unexpected error deleting function: %s", err)
+	}
+
+	_, err := client.GetFunctionDetails(functionOrid)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFunctionDetailsPayloadAcceptsNumericVersion(t *testing.T) {
+	payload := &functionDetailsPayload{Version: []byte("3"), Created: "2024-01-02T03:04:05Z"}
+	details, err := payload.toDetails()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertString(t, details.Version, "3", "Version incorrect")
+	assertInt(t, details.Created.Year(), 2024, "Created year incorrect")
+
+	payload.LastInvoke = "yesterday"
+	if _, err = payload.toDetails(); err == nil {
+		t.Error("Expected error for invalid timestamp")
+	}
+}
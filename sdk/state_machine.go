@@ -2,17 +2,33 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
 )
 
 // StateMachineServiceClient Client to interact with the MDS Cloud state machine service
 type StateMachineServiceClient struct {
 	stateMachineServiceURL string
 	authManager            *AuthManager
+	httpConfig             *httpConfig
+	ctx                    context.Context
+}
+
+// WithContext Returns a copy of the client whose requests carry the context, i.e. for cancellation and tracing
+func (cs *StateMachineServiceClient) WithContext(ctx context.Context) StateMachineServiceAPI {
+	return cs.withContext(ctx)
+}
+
+func (cs *StateMachineServiceClient) withContext(ctx context.Context) *StateMachineServiceClient {
+	client := *cs
+	client.ctx = ctx
+	return &client
 }
 
 // CreateStateMachineArgs Data needed to create a new state machine
@@ -27,7 +43,7 @@ type CreateStateMachineResult struct {
 
 // CreateStateMachine Attempts to create a new state machine within the MDS Cloud deployment
 func (cs *StateMachineServiceClient) CreateStateMachine(data *CreateStateMachineArgs) (*CreateStateMachineResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	client := cs.httpConfig.newClient(orid.ServiceStateMachine, API_TIMEOUT, false)
 
 	// body, err := json.Marshal(data)
 	// if err != nil {
@@ -40,11 +56,12 @@ func (cs *StateMachineServiceClient) CreateStateMachine(data *CreateStateMachine
 		return nil, errors.New("could not build request to create state machine")
 	}
 
-	token, err := cs.authManager.GetAuthenticationToken(nil)
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(cs.ctx, req, "CreateStateMachine", "")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -83,18 +100,23 @@ type GetStateMachineDetailsResult struct {
 
 // GetStateMachineDetails Attempts to fetch the details of a state machine within the MDS Cloud deployment
 func (cs *StateMachineServiceClient) GetStateMachineDetails(data *GetStateMachineDetailsArgs) (*GetStateMachineDetailsResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if _, err := validateOrid(data.Orid, orid.ServiceStateMachine); err != nil {
+		return nil, err
+	}
+
+	client := cs.httpConfig.newClient(orid.ServiceStateMachine, API_TIMEOUT, false)
 
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/machine/%s", cs.stateMachineServiceURL, data.Orid), nil)
 	if err != nil {
 		return nil, errors.New("could not build request to get state machine details")
 	}
 
-	token, err := cs.authManager.GetAuthenticationToken(nil)
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(cs.ctx, req, "GetStateMachineDetails", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -132,7 +154,11 @@ type UpdateStateMachineResult struct {
 
 // UpdateStateMachine Attempts to create a new state machine within the MDS Cloud deployment
 func (cs *StateMachineServiceClient) UpdateStateMachine(data *UpdateStateMachineArgs) (*UpdateStateMachineResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if _, err := validateOrid(data.Orid, orid.ServiceStateMachine); err != nil {
+		return nil, err
+	}
+
+	client := cs.httpConfig.newClient(orid.ServiceStateMachine, API_TIMEOUT, false)
 
 	body := bytes.NewBuffer([]byte(data.Definition))
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/machine/%s", cs.stateMachineServiceURL, data.Orid), body)
@@ -140,11 +166,12 @@ func (cs *StateMachineServiceClient) UpdateStateMachine(data *UpdateStateMachine
 		return nil, errors.New("could not build request to create state machine")
 	}
 
-	token, err := cs.authManager.GetAuthenticationToken(nil)
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(cs.ctx, req, "UpdateStateMachine", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
@@ -181,18 +208,23 @@ type DeleteStateMachineResult struct {
 
 // DeleteStateMachine Attempts to delete a state machine within the MDS Cloud deployment
 func (cs *StateMachineServiceClient) DeleteStateMachine(data *DeleteStateMachineArgs) (*DeleteStateMachineResult, error) {
-	client := &http.Client{Timeout: API_TIMEOUT}
+	if _, err := validateOrid(data.Orid, orid.ServiceStateMachine); err != nil {
+		return nil, err
+	}
+
+	client := cs.httpConfig.newClient(orid.ServiceStateMachine, API_TIMEOUT, false)
 
 	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v1/machine/%s", cs.stateMachineServiceURL, data.Orid), nil)
 	if err != nil {
 		return nil, errors.New("could not build request to create state machine")
 	}
 
-	token, err := cs.authManager.GetAuthenticationToken(nil)
+	token, err := cs.authManager.GetAuthenticationTokenContext(cs.ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req = withOperation(cs.ctx, req, "DeleteStateMachine", data.Orid)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Token", token)
 	r, err := client.Do(req)
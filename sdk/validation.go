@@ -0,0 +1,16 @@
+package sdk
+
+import "github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+
+// validateOrid Ensures the value is a well formed orid belonging to the given service
+func validateOrid(value string, service string) (*orid.Orid, error) {
+	o, err := orid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = o.ExpectService(service); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MadDonkeySoftware/mdsCloudSdkGo/sdk/orid"
+)
+
+func TestClientsRejectOridsOfOtherServices(t *testing.T) {
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+
+	_, err := sdk.GetQueueServiceClient().GetQueueDetails(&GetQueueDetailsArgs{
+		Orid: "orid:1:mdsCloud:::1001:sm:machine",
+	})
+	if err == nil {
+		t.Fatalf("Expected state machine orid to be rejected by queue client")
+	}
+	assertString(t, err.Error(), `orid "orid:1:mdsCloud:::1001:sm:machine" belongs to the state machine service (sm), not the queue service (qs)`, "Error message incorrect")
+}
+
+func TestClientsRejectMalformedOrids(t *testing.T) {
+	sdk := NewSdk("1001", "user", "password", false, false, map[string]string{})
+
+	err := sdk.GetServerlessFunctionsClient().DeleteFunction("not-an-orid")
+	if !errors.Is(err, orid.ErrInvalid) {
+		t.Errorf("Expected invalid orid error, got: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package sdk
+
+import (
+	"context"
+	"time"
+)
+
+// WaitOptions Controls how often the SDK polls while waiting on a long running operation
+//
+// InitialInterval - Delay before the second poll. Defaults to 500 milliseconds.
+// MaxInterval     - Upper bound for the delay between polls. Defaults to 10 seconds.
+// Multiplier      - Factor the delay grows by after each poll. Defaults to 2.
+type WaitOptions struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	opts := WaitOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.InitialInterval <= 0 {
+		opts.InitialInterval = 500 * time.Millisecond
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = 10 * time.Second
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = 2
+	}
+	return opts
+}
+
+// poll Calls check with exponential backoff until it reports done, returns an error or the context ends. A nil
+// ctx never ends.
+func poll(ctx context.Context, opts *WaitOptions, check func() (bool, error)) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	settings := opts.withDefaults()
+	interval := settings.InitialInterval
+
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * settings.Multiplier)
+		if interval > settings.MaxInterval {
+			interval = settings.MaxInterval
+		}
+	}
+}